@@ -50,6 +50,7 @@ func run() error {
 	server, err := agent.NewServer(cfg.CheckersDir, &agent.ServerConfig{
 		ListenAddr: cfg.ListenAddr,
 		Security:   cfg.Security,
+		PluginsDir: cfg.PluginsDir,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create server: %w", err)
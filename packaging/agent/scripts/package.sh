@@ -49,6 +49,7 @@ After=network.target
 Type=simple
 User=serviceradar
 ExecStart=/usr/local/bin/serviceradar-agent
+ExecReload=/bin/kill -HUP \$MAINPID
 Restart=always
 RestartSec=10
 LimitNPROC=512
@@ -50,6 +50,7 @@ After=network.target
 Type=simple
 User=serviceradar
 ExecStart=/usr/local/bin/serviceradar-poller -config /etc/serviceradar/poller.json
+StateDirectory=serviceradar/poller
 Restart=always
 RestartSec=10
 LimitNPROC=512
@@ -97,6 +98,9 @@ cat > "${PKG_ROOT}/etc/serviceradar/poller.json" << EOF
     "core_address": "changeme:50052",
     "listen_addr": ":50053",
     "poll_interval": "30s",
+    "backlog": {
+      "dir": "/var/lib/serviceradar/poller/backlog"
+    },
     "poller_id": "dusk",
     "service_name": "PollerService",
     "service_type": "grpc"
@@ -0,0 +1,394 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/banner_checker.go
+package agent
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBannerTimeout = 5 * time.Second
+	maxBannerLineBytes   = 4096
+	// maxSSHPreambleLines bounds the lines a server may send before its
+	// identification string (RFC 4253, section 4.2).
+	maxSSHPreambleLines = 16
+
+	bannerProtocolSSH     = "ssh"
+	bannerProtocolSMTP    = "smtp"
+	bannerProtocolGeneric = "generic"
+
+	sshClientVersion  = "SSH-2.0-ServiceRadar"
+	sshPacketHeader   = 6 // packet length, padding length and message code
+	sshMsgKexInit     = 20
+	smtpReplyCodeSize = 3
+)
+
+// BannerCheckerConfig describes the banner exchange. Expect is matched
+// against the banner, ExpectReply against the reply to the greeting. The ssh
+// and smtp protocols send their own greeting and have default expectations;
+// the generic protocol only sends Send, before the banner when SendFirst is set.
+type BannerCheckerConfig struct {
+	Address     string   `json:"address"`
+	Protocol    string   `json:"protocol,omitempty"` // ssh, smtp or generic
+	Send        string   `json:"send,omitempty"`
+	SendFirst   bool     `json:"send_first,omitempty"`
+	Expect      string   `json:"expect,omitempty"`
+	ExpectReply string   `json:"expect_reply,omitempty"`
+	Timeout     Duration `json:"timeout,omitempty"`
+}
+
+// BannerChecker connects to a TCP service and verifies that the daemon
+// answers, which a plain port check cannot tell.
+type BannerChecker struct {
+	config      BannerCheckerConfig
+	expect      *regexp.Regexp
+	expectReply *regexp.Regexp
+}
+
+// BannerResponse defines the structure of the banner check result.
+type BannerResponse struct {
+	Address      string `json:"address"`
+	Protocol     string `json:"protocol"`
+	Banner       string `json:"banner,omitempty"`
+	Reply        string `json:"reply,omitempty"`
+	ConnectTime  int64  `json:"connect_time"`
+	BannerTime   int64  `json:"banner_time"` // from connect to banner, in nanoseconds
+	ResponseTime int64  `json:"response_time"`
+	Available    bool   `json:"available"`
+	Error        string `json:"error,omitempty"`
+}
+
+// NewBannerChecker creates a BannerChecker from the check details, which are
+// either "protocol:host[:port]" (e.g. "ssh:10.0.0.1"), "host:port" for a
+// generic banner or a JSON encoded BannerCheckerConfig.
+func NewBannerChecker(details string) (*BannerChecker, error) {
+	details = strings.TrimSpace(details)
+	if details == "" {
+		return nil, errDetailsRequiredBanner
+	}
+
+	var cfg BannerCheckerConfig
+
+	if strings.HasPrefix(details, "{") {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse banner check details: %w", err)
+		}
+	} else {
+		cfg.Address = details
+
+		if protocol, address, found := strings.Cut(details, ":"); found {
+			if _, err := bannerProtocolPort(protocol); err == nil {
+				cfg.Protocol, cfg.Address = protocol, address
+			}
+		}
+	}
+
+	c := &BannerChecker{config: cfg}
+	if err := c.applyDefaults(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *BannerChecker) applyDefaults() error {
+	c.config.Protocol = strings.ToLower(c.config.Protocol)
+	if c.config.Protocol == "" {
+		c.config.Protocol = bannerProtocolGeneric
+	}
+
+	port, err := bannerProtocolPort(c.config.Protocol)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := net.SplitHostPort(c.config.Address); err != nil {
+		if port == 0 {
+			return fmt.Errorf("%w: %s", errBannerPortRequired, c.config.Address)
+		}
+
+		c.config.Address = net.JoinHostPort(c.config.Address, strconv.Itoa(port))
+	}
+
+	if c.config.Timeout == 0 {
+		c.config.Timeout = Duration(defaultBannerTimeout)
+	}
+
+	switch c.config.Protocol {
+	case bannerProtocolSSH:
+		c.config.Expect = cmp.Or(c.config.Expect, `^SSH-(2\.0|1\.99)-`)
+	case bannerProtocolSMTP:
+		c.config.Expect = cmp.Or(c.config.Expect, `^220[ -]`)
+		c.config.ExpectReply = cmp.Or(c.config.ExpectReply, `^250[ -]`)
+	}
+
+	if c.expect, err = compileBannerRegex(c.config.Expect); err != nil {
+		return err
+	}
+
+	c.expectReply, err = compileBannerRegex(c.config.ExpectReply)
+
+	return err
+}
+
+// bannerProtocolPort returns the well-known port of a protocol, or 0 for generic banners.
+func bannerProtocolPort(protocol string) (int, error) {
+	switch protocol {
+	case bannerProtocolGeneric:
+		return 0, nil
+	case bannerProtocolSSH:
+		return 22, nil //nolint:mnd // well-known port
+	case bannerProtocolSMTP:
+		return 25, nil //nolint:mnd // well-known port
+	default:
+		return 0, fmt.Errorf("%w: %s", errUnsupportedBannerProtocol, protocol)
+	}
+}
+
+func compileBannerRegex(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil //nolint:nilnil // no expectation configured
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidBannerRegex, err)
+	}
+
+	return re, nil
+}
+
+// Check connects, runs the protocol exchange and matches the banner and reply.
+func (c *BannerChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout))
+	defer cancel()
+
+	resp := &BannerResponse{Address: c.config.Address, Protocol: c.config.Protocol}
+
+	start := time.Now()
+
+	if err := c.exchange(ctx, resp, start); err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Available = true
+	}
+
+	resp.ResponseTime = time.Since(start).Nanoseconds()
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal banner response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+func (c *BannerChecker) exchange(ctx context.Context, resp *BannerResponse, start time.Time) error {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", c.config.Address)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	resp.ConnectTime = time.Since(start).Nanoseconds()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	reader := bufio.NewReaderSize(conn, maxBannerLineBytes)
+
+	switch c.config.Protocol {
+	case bannerProtocolSSH:
+		err = c.sshExchange(conn, reader, resp, start)
+	case bannerProtocolSMTP:
+		err = c.smtpExchange(conn, reader, resp, start)
+	default:
+		err = c.genericExchange(conn, reader, resp, start)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if c.expect != nil && !c.expect.MatchString(resp.Banner) {
+		return fmt.Errorf("%w: banner %q does not match %q", errBannerMismatch, resp.Banner, c.config.Expect)
+	}
+
+	if c.expectReply != nil && !c.expectReply.MatchString(resp.Reply) {
+		return fmt.Errorf("%w: reply %q does not match %q", errBannerMismatch, resp.Reply, c.config.ExpectReply)
+	}
+
+	return nil
+}
+
+// sshExchange reads the identification string, sends ours and waits for the
+// server's first key exchange packet, which a hung sshd never sends.
+func (c *BannerChecker) sshExchange(conn net.Conn, reader *bufio.Reader, resp *BannerResponse, start time.Time) error {
+	for i := 0; ; i++ {
+		line, err := readBannerLine(reader)
+		if err != nil {
+			return fmt.Errorf("failed to read banner: %w", err)
+		}
+
+		if strings.HasPrefix(line, "SSH-") {
+			resp.Banner = line
+
+			break
+		}
+
+		if i == maxSSHPreambleLines {
+			return fmt.Errorf("%w: no SSH identification string", errUnexpectedReply)
+		}
+	}
+
+	resp.BannerTime = time.Since(start).Nanoseconds()
+
+	if _, err := io.WriteString(conn, cmp.Or(c.config.Send, sshClientVersion)+"\r\n"); err != nil {
+		return fmt.Errorf("failed to send identification string: %w", err)
+	}
+
+	header := make([]byte, sshPacketHeader)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return fmt.Errorf("no key exchange from server: %w", err)
+	}
+
+	if header[sshPacketHeader-1] != sshMsgKexInit {
+		return fmt.Errorf("%w: expected KEXINIT, got message %d", errUnexpectedReply, header[sshPacketHeader-1])
+	}
+
+	return nil
+}
+
+// smtpExchange reads the greeting, sends EHLO and quits politely.
+func (c *BannerChecker) smtpExchange(conn net.Conn, reader *bufio.Reader, resp *BannerResponse, start time.Time) error {
+	banner, err := readSMTPReply(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read banner: %w", err)
+	}
+
+	resp.Banner = banner
+	resp.BannerTime = time.Since(start).Nanoseconds()
+
+	if !c.expect.MatchString(banner) {
+		return nil // reported by the caller; a 421 greeting is not followed by EHLO
+	}
+
+	if _, err := io.WriteString(conn, cmp.Or(c.config.Send, "EHLO serviceradar")+"\r\n"); err != nil {
+		return fmt.Errorf("failed to send greeting: %w", err)
+	}
+
+	if resp.Reply, err = readSMTPReply(reader); err != nil {
+		return fmt.Errorf("failed to read greeting reply: %w", err)
+	}
+
+	_, _ = io.WriteString(conn, "QUIT\r\n")
+
+	return nil
+}
+
+// genericExchange reads one banner line and, when configured, sends a
+// greeting and reads one reply line.
+func (c *BannerChecker) genericExchange(conn net.Conn, reader *bufio.Reader, resp *BannerResponse, start time.Time) error {
+	send := func() error {
+		if c.config.Send == "" {
+			return nil
+		}
+
+		if _, err := io.WriteString(conn, c.config.Send); err != nil {
+			return fmt.Errorf("failed to send greeting: %w", err)
+		}
+
+		return nil
+	}
+
+	if c.config.SendFirst {
+		if err := send(); err != nil {
+			return err
+		}
+	}
+
+	banner, err := readBannerLine(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read banner: %w", err)
+	}
+
+	resp.Banner = banner
+	resp.BannerTime = time.Since(start).Nanoseconds()
+
+	if c.config.SendFirst || c.config.Send == "" {
+		return nil
+	}
+
+	if err := send(); err != nil {
+		return err
+	}
+
+	if resp.Reply, err = readBannerLine(reader); err != nil {
+		return fmt.Errorf("failed to read reply: %w", err)
+	}
+
+	return nil
+}
+
+// readBannerLine reads a line of at most maxBannerLineBytes without its line
+// ending. A longer line is cut at the limit.
+func readBannerLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadSlice('\n')
+	if err != nil && (len(line) == 0 || !errors.Is(err, bufio.ErrBufferFull)) {
+		return "", err
+	}
+
+	return strings.TrimRight(string(line), "\r\n"), nil
+}
+
+// readSMTPReply reads a possibly multi-line SMTP reply and joins its lines
+// with newlines.
+func readSMTPReply(reader *bufio.Reader) (string, error) {
+	var lines []string
+
+	for {
+		line, err := readBannerLine(reader)
+		if err != nil {
+			return "", err
+		}
+
+		lines = append(lines, line)
+
+		// "250-" continues a multi-line reply, "250 " ends it.
+		if len(line) <= smtpReplyCodeSize || line[smtpReplyCodeSize] != '-' {
+			return strings.Join(lines, "\n"), nil
+		}
+	}
+}
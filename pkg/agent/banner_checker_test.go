@@ -0,0 +1,231 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSSH sends its identification string and, once the client sent its own,
+// the header of a KEXINIT packet. A hung daemon accepts but never answers.
+func fakeSSH(banner string, hung bool) func(net.Conn) {
+	return func(conn net.Conn) {
+		if hung {
+			_, _ = io.Copy(io.Discard, conn)
+
+			return
+		}
+
+		_, _ = fmt.Fprintf(conn, "%s\r\n", banner)
+
+		if _, err := bufio.NewReader(conn).ReadString('\n'); err != nil {
+			return
+		}
+
+		_, _ = conn.Write([]byte{0, 0, 0x01, 0x2c, 0x04, sshMsgKexInit})
+	}
+}
+
+// fakeSMTP greets with greeting and answers EHLO with a multi-line reply.
+func fakeSMTP(greeting string) func(net.Conn) {
+	return func(conn net.Conn) {
+		_, _ = fmt.Fprintf(conn, "%s\r\n", greeting)
+
+		reader := bufio.NewReader(conn)
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			switch {
+			case strings.HasPrefix(line, "EHLO"):
+				_, _ = fmt.Fprint(conn, "250-mail.example.com\r\n250-PIPELINING\r\n250 STARTTLS\r\n")
+			case strings.HasPrefix(line, "QUIT"):
+				_, _ = fmt.Fprint(conn, "221 Bye\r\n")
+
+				return
+			}
+		}
+	}
+}
+
+// fakeEcho sends an optional banner and echoes every line in upper case.
+func fakeEcho(banner string) func(net.Conn) {
+	return func(conn net.Conn) {
+		if banner != "" {
+			_, _ = fmt.Fprintf(conn, "%s\r\n", banner)
+		}
+
+		reader := bufio.NewReader(conn)
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			_, _ = fmt.Fprint(conn, strings.ToUpper(line))
+		}
+	}
+}
+
+func TestNewBannerChecker(t *testing.T) {
+	tests := []struct {
+		details      string
+		wantProtocol string
+		wantAddress  string
+		wantErr      error
+	}{
+		{"ssh:10.0.0.1", bannerProtocolSSH, "10.0.0.1:22", nil},
+		{"smtp:mail.example.com:587", bannerProtocolSMTP, "mail.example.com:587", nil},
+		{"ftp.example.com:21", bannerProtocolGeneric, "ftp.example.com:21", nil},
+		{"ftp.example.com", "", "", errBannerPortRequired},
+		{`{"address": "10.0.0.1", "protocol": "telnet"}`, "", "", errUnsupportedBannerProtocol},
+		{`{"address": "10.0.0.1:21", "expect": "("}`, "", "", errInvalidBannerRegex},
+		{"", "", "", errDetailsRequiredBanner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.details, func(t *testing.T) {
+			c, err := NewBannerChecker(tt.details)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProtocol, c.config.Protocol)
+			assert.Equal(t, tt.wantAddress, c.config.Address)
+		})
+	}
+}
+
+func TestBannerCheckerCheck(t *testing.T) {
+	sshd := startFakeServer(t, fakeSSH("SSH-2.0-OpenSSH_9.6", false))
+	hungSSHD := startFakeServer(t, fakeSSH("", true))
+	smtpd := startFakeServer(t, fakeSMTP("220 mail.example.com ESMTP Postfix"))
+	busySMTPD := startFakeServer(t, fakeSMTP("421 Service not available"))
+	ftpd := startFakeServer(t, fakeEcho("220 ProFTPD Server ready"))
+	silent := startFakeServer(t, fakeEcho(""))
+
+	tests := []struct {
+		name       string
+		config     BannerCheckerConfig
+		wantOK     bool
+		wantBanner string
+		wantReply  string
+		wantError  string
+	}{
+		{
+			name:       "ssh",
+			config:     BannerCheckerConfig{Address: sshd, Protocol: bannerProtocolSSH},
+			wantOK:     true,
+			wantBanner: "SSH-2.0-OpenSSH_9.6",
+		},
+		{
+			name:       "ssh version mismatch",
+			config:     BannerCheckerConfig{Address: sshd, Protocol: bannerProtocolSSH, Expect: "OpenSSH_10"},
+			wantBanner: "SSH-2.0-OpenSSH_9.6",
+			wantError:  errBannerMismatch.Error(),
+		},
+		{
+			name:      "hung sshd",
+			config:    BannerCheckerConfig{Address: hungSSHD, Protocol: bannerProtocolSSH},
+			wantError: "failed to read banner",
+		},
+		{
+			name:       "smtp",
+			config:     BannerCheckerConfig{Address: smtpd, Protocol: bannerProtocolSMTP},
+			wantOK:     true,
+			wantBanner: "220 mail.example.com ESMTP Postfix",
+			wantReply:  "250-mail.example.com\n250-PIPELINING\n250 STARTTLS",
+		},
+		{
+			name:       "smtp missing extension",
+			config:     BannerCheckerConfig{Address: smtpd, Protocol: bannerProtocolSMTP, ExpectReply: "(?m)^250[ -]SMTPUTF8"},
+			wantBanner: "220 mail.example.com ESMTP Postfix",
+			wantReply:  "250-mail.example.com\n250-PIPELINING\n250 STARTTLS",
+			wantError:  "does not match",
+		},
+		{
+			name:       "smtp not available",
+			config:     BannerCheckerConfig{Address: busySMTPD, Protocol: bannerProtocolSMTP},
+			wantBanner: "421 Service not available",
+			wantError:  errBannerMismatch.Error(),
+		},
+		{
+			name:       "generic banner",
+			config:     BannerCheckerConfig{Address: ftpd, Expect: "^220 ProFTPD"},
+			wantOK:     true,
+			wantBanner: "220 ProFTPD Server ready",
+		},
+		{
+			name:       "generic greeting",
+			config:     BannerCheckerConfig{Address: ftpd, Send: "noop\r\n", ExpectReply: "^NOOP$"},
+			wantOK:     true,
+			wantBanner: "220 ProFTPD Server ready",
+			wantReply:  "NOOP",
+		},
+		{
+			name:       "send first",
+			config:     BannerCheckerConfig{Address: silent, Send: "ping\n", SendFirst: true, Expect: "^PING$"},
+			wantOK:     true,
+			wantBanner: "PING",
+		},
+		{
+			name:      "no banner",
+			config:    BannerCheckerConfig{Address: silent},
+			wantError: "failed to read banner",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Timeout = Duration(500 * time.Millisecond)
+
+			details, err := json.Marshal(tt.config)
+			require.NoError(t, err)
+
+			c, err := NewBannerChecker(string(details))
+			require.NoError(t, err)
+
+			ok, msg := c.Check(context.Background())
+
+			var resp BannerResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+
+			assert.Equal(t, tt.wantOK, ok, msg)
+			assert.Equal(t, tt.wantBanner, resp.Banner)
+			assert.Equal(t, tt.wantReply, resp.Reply)
+			assert.Contains(t, resp.Error, tt.wantError)
+		})
+	}
+}
@@ -19,6 +19,7 @@ package agent
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -28,11 +29,15 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/carverauto/serviceradar/pkg/checker"
 )
 
 const (
-	partsForPortDetails  = 2
-	maxProcessNameLength = 256
+	partsForPortDetails    = 2
+	maxProcessNameLength   = 256
+	processStrategySystemd = "systemd"
+	processStrategyProcfs  = "procfs"
 )
 
 var (
@@ -48,14 +53,55 @@ type ProcessChecker struct {
 	ProcessName string
 }
 
+// ProcessCheckConfig selects how a process is checked. The systemd strategy
+// only uses Name (the unit name); the procfs strategy matches processes on
+// every criterion that is set.
+type ProcessCheckConfig struct {
+	Strategy     string `json:"strategy,omitempty"` // systemd (default) or procfs
+	Name         string `json:"name,omitempty"`
+	CmdlineRegex string `json:"cmdline_regex,omitempty"`
+	PIDFile      string `json:"pid_file,omitempty"`
+	User         string `json:"user,omitempty"`
+	MinCount     int    `json:"min_count,omitempty"`
+}
+
+// NewProcessChecker creates a process checker from the check details, which
+// are either a systemd unit name or a JSON encoded ProcessCheckConfig.
+func NewProcessChecker(details string) (checker.Checker, error) {
+	if !strings.HasPrefix(strings.TrimSpace(details), "{") {
+		return &ProcessChecker{ProcessName: details}, nil
+	}
+
+	var cfg ProcessCheckConfig
+	if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse process check details: %w", err)
+	}
+
+	switch cfg.Strategy {
+	case "", processStrategySystemd:
+		return &ProcessChecker{ProcessName: cfg.Name}, nil
+	case processStrategyProcfs:
+		return NewProcfsChecker(&cfg)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownProcessStrategy, cfg.Strategy)
+	}
+}
+
 func (p *ProcessChecker) validateProcessName() error {
-	if len(p.ProcessName) > maxProcessNameLength {
-		return fmt.Errorf("%w: process name too long (max %d characters)",
-			errInvalidProcessName, maxProcessNameLength)
+	return validateName(p.ProcessName, "process", errInvalidProcessName)
+}
+
+// validateName ensures a name handed to an external command is short and only
+// contains characters from validServiceName, so it cannot contain paths or
+// shell syntax.
+func validateName(name, kind string, errInvalid error) error {
+	if len(name) > maxProcessNameLength {
+		return fmt.Errorf("%w: %s name too long (max %d characters)",
+			errInvalid, kind, maxProcessNameLength)
 	}
 
-	if !validServiceName.MatchString(p.ProcessName) {
-		return fmt.Errorf("%w: %s", errInvalidCharacters, p.ProcessName)
+	if !validServiceName.MatchString(name) {
+		return fmt.Errorf("%w: %s", errInvalidCharacters, name)
 	}
 
 	return nil
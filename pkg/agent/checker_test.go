@@ -21,6 +21,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/carverauto/serviceradar/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -262,7 +263,7 @@ func TestExternalChecker(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
 
-			checker, err := NewExternalChecker(ctx, tt.serviceName, tt.serviceType, tt.address)
+			checker, err := NewExternalChecker(ctx, tt.serviceName, tt.serviceType, tt.address, nil)
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Nil(t, checker)
@@ -282,6 +283,43 @@ func TestExternalChecker(t *testing.T) {
 	}
 }
 
+func TestCheckerSecurity(t *testing.T) {
+	agentSecurity := &models.SecurityConfig{Mode: "mtls", CertDir: "/etc/serviceradar/certs", Role: models.RolePoller}
+	namedAgent := &models.SecurityConfig{Mode: "mtls", CertDir: "/etc/serviceradar/certs", ServerName: "changeme"}
+	spiffe := &models.SecurityConfig{Mode: "spiffe", TrustDomain: "example.org", ServerName: "dusk-checker"}
+
+	tests := []struct {
+		name           string
+		conf           *CheckerConfig
+		agent          *models.SecurityConfig
+		wantMode       models.SecurityMode
+		wantServerName string
+	}{
+		{"agent fallback", &CheckerConfig{}, agentSecurity, "mtls", "10.0.0.5"},
+		{"checker override", &CheckerConfig{Security: spiffe}, agentSecurity, "spiffe", "dusk-checker"},
+		{"no checker config", nil, agentSecurity, "mtls", "10.0.0.5"},
+		{"agent server name ignored", &CheckerConfig{}, namedAgent, "mtls", "10.0.0.5"},
+		{"checker override with agent server name", &CheckerConfig{Security: spiffe}, namedAgent, "spiffe", "dusk-checker"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			security := checkerSecurity(tt.conf, tt.agent, "10.0.0.5:50052")
+			require.NotNil(t, security)
+			assert.Equal(t, tt.wantMode, security.Mode)
+			assert.Equal(t, tt.wantServerName, security.ServerName)
+			assert.Equal(t, models.RoleAgent, security.Role)
+		})
+	}
+
+	// The shared configs are not modified.
+	assert.Equal(t, models.RolePoller, agentSecurity.Role)
+	assert.Empty(t, agentSecurity.ServerName)
+	assert.Equal(t, "changeme", namedAgent.ServerName)
+
+	assert.Nil(t, checkerSecurity(&CheckerConfig{}, nil, "10.0.0.5:50052"))
+}
+
 func TestSNMPChecker(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,261 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/container_checker.go
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultContainerTimeout = 5 * time.Second
+	defaultDockerSocket     = "/var/run/docker.sock"
+	maxDockerBodyBytes      = 4 << 20
+
+	containerHealthUnhealthy = "unhealthy"
+)
+
+// ContainerCheckerConfig lists the containers that must be running. Names
+// may also be container ids. MaxRestarts of zero disables the restart check.
+type ContainerCheckerConfig struct {
+	Socket      string   `json:"socket,omitempty"`
+	Containers  []string `json:"containers"`
+	MaxRestarts int      `json:"max_restarts,omitempty"`
+	Timeout     Duration `json:"timeout,omitempty"`
+}
+
+// ContainerChecker inspects containers through the Docker Engine API on a
+// local unix socket. Podman's Docker compatible socket works as well.
+type ContainerChecker struct {
+	config ContainerCheckerConfig
+	client *http.Client
+}
+
+// ContainerStatus holds the state of a single container.
+type ContainerStatus struct {
+	Name         string `json:"name"`
+	ID           string `json:"id,omitempty"`
+	Image        string `json:"image,omitempty"`
+	Status       string `json:"status,omitempty"`
+	Running      bool   `json:"running"`
+	Health       string `json:"health,omitempty"` // empty without a HEALTHCHECK
+	RestartCount int    `json:"restart_count"`
+	StartedAt    string `json:"started_at,omitempty"`
+	Uptime       int64  `json:"uptime"` // in nanoseconds
+	Error        string `json:"error,omitempty"`
+}
+
+// ContainerResponse defines the structure of the container check result.
+type ContainerResponse struct {
+	Containers   []ContainerStatus `json:"containers"`
+	Running      int               `json:"running"`
+	ResponseTime int64             `json:"response_time"`
+	Available    bool              `json:"available"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// dockerInspect is the subset of GET /containers/{id}/json used by the check.
+type dockerInspect struct {
+	ID           string `json:"Id"`
+	Name         string `json:"Name"`
+	RestartCount int    `json:"RestartCount"`
+	Config       struct {
+		Image string `json:"Image"`
+	} `json:"Config"`
+	State struct {
+		Status     string    `json:"Status"`
+		Running    bool      `json:"Running"`
+		Restarting bool      `json:"Restarting"`
+		StartedAt  time.Time `json:"StartedAt"`
+		Health     *struct {
+			Status string `json:"Status"`
+		} `json:"Health"`
+	} `json:"State"`
+}
+
+// dockerError is the body the Engine API returns with error status codes.
+type dockerError struct {
+	Message string `json:"message"`
+}
+
+// NewContainerChecker creates a ContainerChecker from the check details,
+// which are either a comma separated list of container names or a JSON
+// encoded ContainerCheckerConfig.
+func NewContainerChecker(details string) (*ContainerChecker, error) {
+	details = strings.TrimSpace(details)
+
+	var cfg ContainerCheckerConfig
+
+	if strings.HasPrefix(details, "{") {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse container check details: %w", err)
+		}
+	} else if details != "" {
+		for _, name := range strings.Split(details, ",") {
+			cfg.Containers = append(cfg.Containers, strings.TrimSpace(name))
+		}
+	}
+
+	if len(cfg.Containers) == 0 {
+		return nil, errDetailsRequiredContainer
+	}
+
+	if cfg.Socket == "" {
+		cfg.Socket = defaultDockerSocket
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = Duration(defaultContainerTimeout)
+	}
+
+	socket := cfg.Socket
+
+	return &ContainerChecker{
+		config: cfg,
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var dialer net.Dialer
+
+					return dialer.DialContext(ctx, "unix", socket)
+				},
+			},
+		},
+	}, nil
+}
+
+// Check inspects every container and fails unless all of them are running,
+// not unhealthy and within the restart limit.
+func (c *ContainerChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout))
+	defer cancel()
+
+	resp := &ContainerResponse{Containers: make([]ContainerStatus, 0, len(c.config.Containers))}
+
+	start := time.Now()
+
+	var failed []string
+
+	for _, name := range c.config.Containers {
+		status := c.inspect(ctx, name)
+		if status.Running {
+			resp.Running++
+		}
+
+		if status.Error != "" {
+			failed = append(failed, name)
+		}
+
+		resp.Containers = append(resp.Containers, status)
+	}
+
+	resp.ResponseTime = time.Since(start).Nanoseconds()
+
+	if len(failed) > 0 {
+		resp.Error = fmt.Sprintf("%v: %s", errContainerNotHealthy, strings.Join(failed, ", "))
+	} else {
+		resp.Available = true
+	}
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal container response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+func (c *ContainerChecker) inspect(ctx context.Context, name string) ContainerStatus {
+	status := ContainerStatus{Name: name}
+
+	var inspect dockerInspect
+
+	if err := c.get(ctx, "/containers/"+url.PathEscape(name)+"/json", &inspect); err != nil {
+		status.Error = err.Error()
+
+		return status
+	}
+
+	status.ID = inspect.ID
+	status.Image = inspect.Config.Image
+	status.Status = inspect.State.Status
+	status.Running = inspect.State.Running && !inspect.State.Restarting
+	status.RestartCount = inspect.RestartCount
+
+	if inspect.State.Health != nil {
+		status.Health = inspect.State.Health.Status
+	}
+
+	if status.Running && !inspect.State.StartedAt.IsZero() {
+		status.StartedAt = inspect.State.StartedAt.Format(time.RFC3339)
+		status.Uptime = time.Since(inspect.State.StartedAt).Nanoseconds()
+	}
+
+	switch {
+	case !status.Running:
+		status.Error = "container is " + inspect.State.Status
+	case status.Health == containerHealthUnhealthy:
+		status.Error = "container health check is failing"
+	case c.config.MaxRestarts > 0 && status.RestartCount > c.config.MaxRestarts:
+		status.Error = fmt.Sprintf("restarted %d times, more than %d", status.RestartCount, c.config.MaxRestarts)
+	}
+
+	return status
+}
+
+func (c *ContainerChecker) get(ctx context.Context, path string, v interface{}) error {
+	// The host is ignored by the unix socket dialer.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://docker"+path, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	r, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach the Docker API: %w", err)
+	}
+	defer func() { _ = r.Body.Close() }()
+
+	body := io.LimitReader(r.Body, maxDockerBodyBytes)
+
+	if r.StatusCode != http.StatusOK {
+		var apiErr dockerError
+
+		if err := json.NewDecoder(body).Decode(&apiErr); err != nil || apiErr.Message == "" {
+			apiErr.Message = r.Status
+		}
+
+		return fmt.Errorf("%w: %s", errDockerAPI, apiErr.Message)
+	}
+
+	if err := json.NewDecoder(body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode Docker API response: %w", err)
+	}
+
+	return nil
+}
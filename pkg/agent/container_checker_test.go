@@ -0,0 +1,181 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startFakeDocker serves container inspect results on a unix socket, the way
+// the Docker Engine API does.
+func startFakeDocker(t *testing.T, containers map[string]string) string {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "docker") // t.TempDir() may exceed the socket path limit
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "docker.sock")
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/containers/"), "/json")
+
+		body, ok := containers[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprintf(w, `{"message": "No such container: %s"}`, name)
+
+			return
+		}
+
+		_, _ = fmt.Fprint(w, body)
+	}))
+	server.Listener = listener
+	server.Start()
+
+	t.Cleanup(server.Close)
+
+	return socket
+}
+
+func dockerContainer(name, status string, restarts int, health string) string {
+	healthJSON := "null"
+	if health != "" {
+		healthJSON = fmt.Sprintf(`{"Status": %q, "FailingStreak": 0}`, health)
+	}
+
+	return fmt.Sprintf(`{
+		"Id": "%s0123456789",
+		"Name": "/%s",
+		"RestartCount": %d,
+		"Config": {"Image": "nginx:1.27"},
+		"State": {
+			"Status": %q,
+			"Running": %t,
+			"Restarting": false,
+			"StartedAt": %q,
+			"Health": %s
+		}
+	}`, name, name, restarts, status, status == "running",
+		time.Now().Add(-time.Hour).UTC().Format(time.RFC3339Nano), healthJSON)
+}
+
+func TestNewContainerChecker(t *testing.T) {
+	c, err := NewContainerChecker("web, db")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web", "db"}, c.config.Containers)
+	assert.Equal(t, defaultDockerSocket, c.config.Socket)
+
+	_, err = NewContainerChecker(`{"socket": "/run/podman/podman.sock"}`)
+	require.ErrorIs(t, err, errDetailsRequiredContainer)
+}
+
+func TestContainerCheckerCheck(t *testing.T) {
+	socket := startFakeDocker(t, map[string]string{
+		"web":       dockerContainer("web", "running", 0, "healthy"),
+		"worker":    dockerContainer("worker", "running", 7, ""),
+		"db":        dockerContainer("db", "running", 0, "unhealthy"),
+		"migration": dockerContainer("migration", "exited", 0, ""),
+	})
+
+	tests := []struct {
+		name       string
+		containers []string
+		maxRestart int
+		wantOK     bool
+		wantError  string
+	}{
+		{"running and healthy", []string{"web", "worker"}, 0, true, ""},
+		{"too many restarts", []string{"web", "worker"}, 5, false, "restarted 7 times"},
+		{"unhealthy", []string{"db"}, 0, false, "health check is failing"},
+		{"exited", []string{"migration"}, 0, false, "container is exited"},
+		{"missing", []string{"cache"}, 0, false, "No such container: cache"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			details, err := json.Marshal(ContainerCheckerConfig{Socket: socket, Containers: tt.containers, MaxRestarts: tt.maxRestart})
+			require.NoError(t, err)
+
+			c, err := NewContainerChecker(string(details))
+			require.NoError(t, err)
+
+			ok, msg := c.Check(context.Background())
+
+			var resp ContainerResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+
+			assert.Equal(t, tt.wantOK, ok, msg)
+			require.Len(t, resp.Containers, len(tt.containers))
+
+			if tt.wantOK {
+				assert.Empty(t, resp.Error)
+			} else {
+				assert.Contains(t, resp.Error, errContainerNotHealthy.Error())
+				assert.Contains(t, msg, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestContainerCheckerStatus(t *testing.T) {
+	socket := startFakeDocker(t, map[string]string{"web": dockerContainer("web", "running", 2, "healthy")})
+
+	c, err := NewContainerChecker(fmt.Sprintf(`{"socket": %q, "containers": ["web"]}`, socket))
+	require.NoError(t, err)
+
+	ok, msg := c.Check(context.Background())
+	require.True(t, ok, msg)
+
+	var resp ContainerResponse
+	require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+
+	status := resp.Containers[0]
+	assert.Equal(t, "web0123456789", status.ID)
+	assert.Equal(t, "nginx:1.27", status.Image)
+	assert.Equal(t, "healthy", status.Health)
+	assert.Equal(t, 2, status.RestartCount)
+	assert.True(t, status.Running)
+	assert.InDelta(t, time.Hour, time.Duration(status.Uptime), float64(time.Minute))
+	assert.Equal(t, 1, resp.Running)
+}
+
+func TestContainerCheckerSocketUnavailable(t *testing.T) {
+	c, err := NewContainerChecker(fmt.Sprintf(`{"socket": %q, "containers": ["web"]}`, filepath.Join(t.TempDir(), "missing.sock")))
+	require.NoError(t, err)
+
+	ok, msg := c.Check(context.Background())
+	assert.False(t, ok)
+	assert.Contains(t, msg, "failed to reach the Docker API")
+}
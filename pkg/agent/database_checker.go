@@ -0,0 +1,355 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/database_checker.go
+package agent
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq" // registers the postgres database/sql driver
+)
+
+const (
+	defaultDatabaseTimeout = 10 * time.Second
+	defaultPostgresPort    = "5432"
+	defaultMySQLPort       = "3306"
+	defaultSQLProbe        = "SELECT 1"
+
+	sslModeDisable    = "disable"
+	sslModeRequire    = "require"
+	sslModeVerifyCA   = "verify-ca"
+	sslModeVerifyFull = "verify-full"
+
+	databaseRolePrimary = "primary"
+	databaseRoleReplica = "replica"
+)
+
+// DatabaseCheckerConfig is shared by the postgres, mysql and redis checkers.
+// Credentials are usually kept in the agent's checker configuration rather
+// than in the poller's check details. Query is the probe: a SQL statement for
+// postgres and mysql, a command line for redis.
+type DatabaseCheckerConfig struct {
+	Address  string   `json:"address"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	Database string   `json:"database,omitempty"`
+	Query    string   `json:"query,omitempty"`
+	SSLMode  string   `json:"ssl_mode,omitempty"` // disable, require, verify-ca or verify-full
+	Timeout  Duration `json:"timeout,omitempty"`
+}
+
+// DatabaseResponse defines the structure of the database check result.
+type DatabaseResponse struct {
+	Address      string `json:"address"`
+	Version      string `json:"version,omitempty"`
+	Role         string `json:"role,omitempty"`
+	ProbeResult  string `json:"probe_result,omitempty"`
+	ConnectTime  int64  `json:"connect_time"`
+	ProbeTime    int64  `json:"probe_time"`
+	ResponseTime int64  `json:"response_time"`
+	Available    bool   `json:"available"`
+	Error        string `json:"error,omitempty"`
+}
+
+// SQLChecker checks a database through a database/sql driver: it logs in,
+// runs the probe query and reads the server version and replication role.
+type SQLChecker struct {
+	config     DatabaseCheckerConfig
+	driver     string
+	dsn        string
+	versionSQL string
+	role       func(ctx context.Context, conn *sql.Conn) (string, error)
+}
+
+// parseDatabaseDetails reads check details that are either a plain address
+// or a JSON encoded DatabaseCheckerConfig, and fills in defaults.
+func parseDatabaseDetails(details, defaultPort string) (DatabaseCheckerConfig, error) {
+	var cfg DatabaseCheckerConfig
+
+	details = strings.TrimSpace(details)
+	if details == "" {
+		return cfg, errDetailsRequiredDatabase
+	}
+
+	if strings.HasPrefix(details, "{") {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return cfg, fmt.Errorf("failed to parse database check details: %w", err)
+		}
+	} else {
+		cfg.Address = details
+	}
+
+	if cfg.Address == "" {
+		return cfg, errDetailsRequiredDatabase
+	}
+
+	if _, _, err := net.SplitHostPort(cfg.Address); err != nil {
+		cfg.Address = net.JoinHostPort(cfg.Address, defaultPort)
+	}
+
+	switch cfg.SSLMode {
+	case "", sslModeDisable, sslModeRequire, sslModeVerifyCA, sslModeVerifyFull:
+	default:
+		return cfg, fmt.Errorf("%w: %s", errUnsupportedSSLMode, cfg.SSLMode)
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = Duration(defaultDatabaseTimeout)
+	}
+
+	return cfg, nil
+}
+
+// NewPostgresChecker creates a SQLChecker for a PostgreSQL server.
+func NewPostgresChecker(details string) (*SQLChecker, error) {
+	cfg, err := parseDatabaseDetails(details, defaultPostgresPort)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.SSLMode == "" {
+		cfg.SSLMode = sslModeRequire
+	}
+
+	if cfg.Query == "" {
+		cfg.Query = defaultSQLProbe
+	}
+
+	query := url.Values{}
+	query.Set("sslmode", cfg.SSLMode)
+	query.Set("connect_timeout", strconv.Itoa(int(time.Duration(cfg.Timeout).Seconds())+1))
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		Host:     cfg.Address,
+		Path:     "/" + cfg.Database,
+		RawQuery: query.Encode(),
+	}
+
+	if cfg.Username != "" {
+		dsn.User = url.UserPassword(cfg.Username, cfg.Password)
+	}
+
+	return &SQLChecker{
+		config:     cfg,
+		driver:     "postgres",
+		dsn:        dsn.String(),
+		versionSQL: "SHOW server_version",
+		role:       postgresRole,
+	}, nil
+}
+
+// NewMySQLChecker creates a SQLChecker for a MySQL or MariaDB server.
+func NewMySQLChecker(details string) (*SQLChecker, error) {
+	cfg, err := parseDatabaseDetails(details, defaultMySQLPort)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Query == "" {
+		cfg.Query = defaultSQLProbe
+	}
+
+	mysqlCfg := mysql.NewConfig()
+	mysqlCfg.User = cfg.Username
+	mysqlCfg.Passwd = cfg.Password
+	mysqlCfg.Net = "tcp"
+	mysqlCfg.Addr = cfg.Address
+	mysqlCfg.DBName = cfg.Database
+	mysqlCfg.Timeout = time.Duration(cfg.Timeout)
+
+	switch cfg.SSLMode {
+	case "":
+		mysqlCfg.TLSConfig = "preferred"
+	case sslModeDisable:
+		mysqlCfg.TLSConfig = "false"
+	case sslModeRequire:
+		mysqlCfg.TLSConfig = "skip-verify"
+	default:
+		mysqlCfg.TLSConfig = "true"
+	}
+
+	return &SQLChecker{
+		config:     cfg,
+		driver:     "mysql",
+		dsn:        mysqlCfg.FormatDSN(),
+		versionSQL: "SELECT VERSION()",
+		role:       mysqlRole,
+	}, nil
+}
+
+// Check opens a fresh connection, so a failing login is always noticed.
+func (c *SQLChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout))
+	defer cancel()
+
+	resp := &DatabaseResponse{Address: c.config.Address}
+
+	start := time.Now()
+
+	if err := c.probe(ctx, resp); err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Available = true
+	}
+
+	resp.ResponseTime = time.Since(start).Nanoseconds()
+
+	return marshalDatabaseResponse(resp)
+}
+
+func (c *SQLChecker) probe(ctx context.Context, resp *DatabaseResponse) error {
+	db, err := sql.Open(c.driver, c.dsn)
+	if err != nil {
+		return fmt.Errorf("failed to open %s connection: %w", c.driver, err)
+	}
+	defer func() { _ = db.Close() }()
+
+	start := time.Now()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	resp.ConnectTime = time.Since(start).Nanoseconds()
+	start = time.Now()
+
+	if resp.ProbeResult, err = queryProbe(ctx, conn, c.config.Query); err != nil {
+		return fmt.Errorf("probe query failed: %w", err)
+	}
+
+	resp.ProbeTime = time.Since(start).Nanoseconds()
+
+	// Version and role are informational; a user without the privileges to
+	// read them still passes the check.
+	if err := conn.QueryRowContext(ctx, c.versionSQL).Scan(&resp.Version); err != nil {
+		log.Printf("Failed to read %s server version from %s: %v", c.driver, c.config.Address, err)
+	}
+
+	if resp.Role, err = c.role(ctx, conn); err != nil {
+		log.Printf("Failed to read %s replication role from %s: %v", c.driver, c.config.Address, err)
+	}
+
+	return nil
+}
+
+// queryProbe runs the probe query and returns the columns of its first row
+// joined with ", ". A query that returns no rows passes with an empty result.
+func queryProbe(ctx context.Context, conn *sql.Conn, query string) (string, error) {
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	if !rows.Next() {
+		return "", rows.Err()
+	}
+
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	if err := rows.Scan(dest...); err != nil {
+		return "", err
+	}
+
+	fields := make([]string, len(values))
+
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+		case []byte:
+			fields[i] = string(v)
+		default:
+			fields[i] = fmt.Sprint(v)
+		}
+	}
+
+	return strings.Join(fields, ", "), nil
+}
+
+func postgresRole(ctx context.Context, conn *sql.Conn) (string, error) {
+	var inRecovery bool
+
+	if err := conn.QueryRowContext(ctx, "SELECT pg_is_in_recovery()").Scan(&inRecovery); err != nil {
+		return "", err
+	}
+
+	if inRecovery {
+		return databaseRoleReplica, nil
+	}
+
+	return databaseRolePrimary, nil
+}
+
+// mysqlRole reports a replica when replication is configured. SHOW REPLICA
+// STATUS replaced SHOW SLAVE STATUS in MySQL 8.0.22.
+func mysqlRole(ctx context.Context, conn *sql.Conn) (string, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	for _, statement := range []string{"SHOW REPLICA STATUS", "SHOW SLAVE STATUS"} {
+		if rows, err = conn.QueryContext(ctx, statement); err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if rows.Next() {
+		return databaseRoleReplica, nil
+	}
+
+	return databaseRolePrimary, rows.Err()
+}
+
+func marshalDatabaseResponse(resp *DatabaseResponse) (isAvailable bool, statusMsg string) {
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal database response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
@@ -0,0 +1,490 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec // mysql_native_password is defined in terms of SHA-1
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fakeDBUser     = "monitor"
+	fakeDBPassword = "s3cret"
+)
+
+// startFakeServer accepts connections on a local port and hands each one to handle.
+func startFakeServer(t *testing.T, handle func(net.Conn)) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer func() { _ = conn.Close() }()
+
+				handle(conn)
+			}()
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
+// fakePostgres implements startup with cleartext password authentication and
+// the simple query protocol, answering a fixed set of queries.
+type fakePostgres struct {
+	inRecovery bool
+}
+
+func pgMessage(typ byte, body []byte) []byte {
+	msg := make([]byte, 5, 5+len(body))
+	msg[0] = typ
+	binary.BigEndian.PutUint32(msg[1:], uint32(len(body)+4)) //nolint:gosec // test messages are small
+
+	return append(msg, body...)
+}
+
+func pgInt32(v uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, v)
+}
+
+func pgError(code, message string) []byte {
+	return pgMessage('E', []byte("SFATAL\x00C"+code+"\x00M"+message+"\x00\x00"))
+}
+
+func (f *fakePostgres) handle(conn net.Conn) {
+	r := bufio.NewReader(conn)
+
+	// Startup message: length, protocol version, then key/value pairs.
+	var length uint32
+	if binary.Read(r, binary.BigEndian, &length) != nil {
+		return
+	}
+
+	startup := make([]byte, length-4)
+	if _, err := io.ReadFull(r, startup); err != nil {
+		return
+	}
+
+	_, _ = conn.Write(pgMessage('R', pgInt32(3))) // AuthenticationCleartextPassword
+
+	typ, body, err := readPGMessage(r)
+	if err != nil || typ != 'p' {
+		return
+	}
+
+	if !bytes.Contains(startup, []byte("user\x00"+fakeDBUser+"\x00")) || string(bytes.TrimRight(body, "\x00")) != fakeDBPassword {
+		_, _ = conn.Write(pgError("28P01", "password authentication failed for user"))
+
+		return
+	}
+
+	_, _ = conn.Write(pgMessage('R', pgInt32(0)))
+	_, _ = conn.Write(pgMessage('S', []byte("server_version\x0016.2\x00")))
+	_, _ = conn.Write(pgMessage('Z', []byte("I")))
+
+	for {
+		typ, body, err := readPGMessage(r)
+		if err != nil || typ != 'Q' {
+			return
+		}
+
+		_, _ = conn.Write(f.answer(strings.TrimRight(string(body), "\x00")))
+	}
+}
+
+func (f *fakePostgres) answer(query string) []byte {
+	const (
+		oidBool = 16
+		oidText = 25
+	)
+
+	inRecovery := "f"
+	if f.inRecovery {
+		inRecovery = "t"
+	}
+
+	answers := map[string]struct {
+		values []string
+		oid    uint32
+	}{
+		"SELECT 1":                   {[]string{"1"}, oidText},
+		"SELECT 1, 'ok'":             {[]string{"1", "ok"}, oidText},
+		"SHOW server_version":        {[]string{"16.2"}, oidText},
+		"SELECT pg_is_in_recovery()": {[]string{inRecovery}, oidBool},
+	}
+
+	ready := pgMessage('Z', []byte("I"))
+
+	a, ok := answers[query]
+	if !ok {
+		return append(pgError("42601", "syntax error"), ready...)
+	}
+
+	var desc, row []byte
+
+	desc = binary.BigEndian.AppendUint16(desc, uint16(len(a.values))) //nolint:gosec // test rows are small
+	row = binary.BigEndian.AppendUint16(row, uint16(len(a.values)))   //nolint:gosec // test rows are small
+
+	for i, value := range a.values {
+		desc = append(desc, fmt.Sprintf("col%d\x00", i)...)
+		desc = binary.BigEndian.AppendUint32(desc, 0)
+		desc = binary.BigEndian.AppendUint16(desc, 0)
+		desc = binary.BigEndian.AppendUint32(desc, a.oid)
+		desc = binary.BigEndian.AppendUint16(desc, 0xffff)
+		desc = binary.BigEndian.AppendUint32(desc, 0xffffffff)
+		desc = binary.BigEndian.AppendUint16(desc, 0)
+
+		row = binary.BigEndian.AppendUint32(row, uint32(len(value))) //nolint:gosec // test values are small
+		row = append(row, value...)
+	}
+
+	out := pgMessage('T', desc)
+	out = append(out, pgMessage('D', row)...)
+	out = append(out, pgMessage('C', []byte("SELECT 1\x00"))...)
+
+	return append(out, ready...)
+}
+
+func readPGMessage(r *bufio.Reader) (byte, []byte, error) {
+	typ, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return 0, nil, err
+	}
+
+	body := make([]byte, length-4)
+	_, err = io.ReadFull(r, body)
+
+	return typ, body, err
+}
+
+// fakeMySQL implements the v10 handshake with mysql_native_password and
+// COM_QUERY text result sets for a fixed set of queries.
+type fakeMySQL struct {
+	replica bool
+}
+
+var fakeMySQLNonce = []byte("abcdefghijklmnopqrst") //nolint:gochecknoglobals // test fixture
+
+func mysqlPacket(seq byte, payload []byte) []byte {
+	header := []byte{byte(len(payload)), byte(len(payload) >> 8), byte(len(payload) >> 16), seq}
+
+	return append(header, payload...)
+}
+
+func mysqlLenEnc(s string) []byte {
+	return append([]byte{byte(len(s))}, s...)
+}
+
+func mysqlNativeScramble(password string) []byte {
+	stage1 := sha1.Sum([]byte(password))                                            //nolint:gosec // protocol defined
+	stage2 := sha1.Sum(stage1[:])                                                   //nolint:gosec // protocol defined
+	scramble := sha1.Sum(append(append([]byte{}, fakeMySQLNonce...), stage2[:]...)) //nolint:gosec // protocol defined
+
+	for i := range scramble {
+		scramble[i] ^= stage1[i]
+	}
+
+	return scramble[:]
+}
+
+func (f *fakeMySQL) handle(conn net.Conn) {
+	const (
+		capabilities = 0x00000001 | 0x00000008 | 0x00000200 | 0x00002000 | 0x00008000 | 0x00080000
+	)
+
+	hs := []byte{10}
+	hs = append(hs, "8.0.36-fake\x00"...)
+	hs = binary.LittleEndian.AppendUint32(hs, 1)
+	hs = append(hs, fakeMySQLNonce[:8]...)
+	hs = append(hs, 0)
+	hs = binary.LittleEndian.AppendUint16(hs, capabilities&0xffff)
+	hs = append(hs, 0x21)
+	hs = binary.LittleEndian.AppendUint16(hs, 2)
+	hs = binary.LittleEndian.AppendUint16(hs, capabilities>>16)
+	hs = append(hs, 21)
+	hs = append(hs, make([]byte, 10)...)
+	hs = append(hs, fakeMySQLNonce[8:]...)
+	hs = append(hs, 0)
+	hs = append(hs, "mysql_native_password\x00"...)
+
+	_, _ = conn.Write(mysqlPacket(0, hs))
+
+	r := bufio.NewReader(conn)
+
+	_, resp, err := readMySQLPacket(r)
+	if err != nil || len(resp) < 33 {
+		return
+	}
+
+	// capabilities(4) max packet(4) charset(1) filler(23), then user\0 and the auth response.
+	rest := resp[32:]
+	user, rest, _ := bytes.Cut(rest, []byte{0})
+	authLen := int(rest[0])
+	auth := rest[1 : 1+authLen]
+
+	if string(user) != fakeDBUser || !bytes.Equal(auth, mysqlNativeScramble(fakeDBPassword)) {
+		_, _ = conn.Write(mysqlPacket(2, []byte("\xff\x15\x04#28000Access denied for user")))
+
+		return
+	}
+
+	_, _ = conn.Write(mysqlPacket(2, []byte{0, 0, 0, 2, 0, 0, 0}))
+
+	for {
+		_, cmd, err := readMySQLPacket(r)
+		if err != nil || len(cmd) == 0 || cmd[0] != 0x03 {
+			return
+		}
+
+		_, _ = conn.Write(f.answer(string(cmd[1:])))
+	}
+}
+
+func (f *fakeMySQL) answer(query string) []byte {
+	answers := map[string][]string{
+		"SELECT 1":            {"1"},
+		"SELECT VERSION()":    {"8.0.36-fake"},
+		"SHOW REPLICA STATUS": {},
+	}
+
+	if f.replica {
+		answers["SHOW REPLICA STATUS"] = []string{"primary.example.com"}
+	}
+
+	rows, ok := answers[query]
+	if !ok {
+		return mysqlPacket(1, []byte("\xff\x28\x04#42000You have an error in your SQL syntax"))
+	}
+
+	var column []byte
+	for _, s := range []string{"def", "", "", "", "col", ""} {
+		column = append(column, mysqlLenEnc(s)...)
+	}
+
+	column = append(column, 0x0c, 0x21, 0, 0xff, 0, 0, 0, 0xfd, 0, 0, 0, 0, 0)
+
+	eof := []byte{0xfe, 0, 0, 2, 0}
+	seq := byte(1)
+
+	out := mysqlPacket(seq, []byte{1})
+	seq++
+	out = append(out, mysqlPacket(seq, column)...)
+	seq++
+	out = append(out, mysqlPacket(seq, eof)...)
+
+	for _, row := range rows {
+		seq++
+		out = append(out, mysqlPacket(seq, mysqlLenEnc(row))...)
+	}
+
+	seq++
+
+	return append(out, mysqlPacket(seq, eof)...)
+}
+
+func readMySQLPacket(r *bufio.Reader) (byte, []byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+
+	payload := make([]byte, int(header[0])|int(header[1])<<8|int(header[2])<<16)
+	_, err := io.ReadFull(r, payload)
+
+	return header[3], payload, err
+}
+
+func TestParseDatabaseDetails(t *testing.T) {
+	tests := []struct {
+		name        string
+		details     string
+		wantAddress string
+		wantError   bool
+	}{
+		{name: "host only", details: "db.example.com", wantAddress: "db.example.com:5432"},
+		{name: "host and port", details: "db.example.com:6543", wantAddress: "db.example.com:6543"},
+		{name: "json", details: `{"address": "10.0.0.5", "username": "u"}`, wantAddress: "10.0.0.5:5432"},
+		{name: "empty", details: "", wantError: true},
+		{name: "missing address", details: `{"username": "u"}`, wantError: true},
+		{name: "bad ssl mode", details: `{"address": "db", "ssl_mode": "maybe"}`, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := parseDatabaseDetails(tt.details, defaultPostgresPort)
+			if tt.wantError {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAddress, cfg.Address)
+			assert.Equal(t, Duration(defaultDatabaseTimeout), cfg.Timeout)
+		})
+	}
+}
+
+func databaseDetails(address, password, query string) string {
+	details, _ := json.Marshal(DatabaseCheckerConfig{
+		Address:  address,
+		Username: fakeDBUser,
+		Password: password,
+		Query:    query,
+		SSLMode:  sslModeDisable,
+	})
+
+	return string(details)
+}
+
+func TestSQLCheckerCheck(t *testing.T) {
+	postgres := startFakeServer(t, (&fakePostgres{}).handle)
+	postgresReplica := startFakeServer(t, (&fakePostgres{inRecovery: true}).handle)
+	mysqlPrimary := startFakeServer(t, (&fakeMySQL{}).handle)
+	mysqlReplica := startFakeServer(t, (&fakeMySQL{replica: true}).handle)
+
+	tests := []struct {
+		name        string
+		newChecker  func(string) (*SQLChecker, error)
+		details     string
+		wantOK      bool
+		wantVersion string
+		wantRole    string
+		wantProbe   string
+		wantError   string
+	}{
+		{
+			name:        "postgres primary",
+			newChecker:  NewPostgresChecker,
+			details:     databaseDetails(postgres, fakeDBPassword, ""),
+			wantOK:      true,
+			wantVersion: "16.2",
+			wantRole:    databaseRolePrimary,
+			wantProbe:   "1",
+		},
+		{
+			name:        "postgres replica",
+			newChecker:  NewPostgresChecker,
+			details:     databaseDetails(postgresReplica, fakeDBPassword, ""),
+			wantOK:      true,
+			wantVersion: "16.2",
+			wantRole:    databaseRoleReplica,
+			wantProbe:   "1",
+		},
+		{
+			name:        "postgres multi-column probe",
+			newChecker:  NewPostgresChecker,
+			details:     databaseDetails(postgres, fakeDBPassword, "SELECT 1, 'ok'"),
+			wantOK:      true,
+			wantVersion: "16.2",
+			wantRole:    databaseRolePrimary,
+			wantProbe:   "1, ok",
+		},
+		{
+			name:       "postgres bad password",
+			newChecker: NewPostgresChecker,
+			details:    databaseDetails(postgres, "wrong", ""),
+			wantError:  "password authentication failed",
+		},
+		{
+			name:       "postgres failing probe",
+			newChecker: NewPostgresChecker,
+			details:    databaseDetails(postgres, fakeDBPassword, "SELECT broken"),
+			wantError:  "probe query failed",
+		},
+		{
+			name:        "mysql primary",
+			newChecker:  NewMySQLChecker,
+			details:     databaseDetails(mysqlPrimary, fakeDBPassword, ""),
+			wantOK:      true,
+			wantVersion: "8.0.36-fake",
+			wantRole:    databaseRolePrimary,
+			wantProbe:   "1",
+		},
+		{
+			name:        "mysql replica",
+			newChecker:  NewMySQLChecker,
+			details:     databaseDetails(mysqlReplica, fakeDBPassword, ""),
+			wantOK:      true,
+			wantVersion: "8.0.36-fake",
+			wantRole:    databaseRoleReplica,
+			wantProbe:   "1",
+		},
+		{
+			name:       "mysql bad password",
+			newChecker: NewMySQLChecker,
+			details:    databaseDetails(mysqlPrimary, "wrong", ""),
+			wantError:  "Access denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := tt.newChecker(tt.details)
+			require.NoError(t, err)
+
+			ok, msg := c.Check(context.Background())
+			assert.Equal(t, tt.wantOK, ok, msg)
+
+			var resp DatabaseResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+			assert.Equal(t, tt.wantVersion, resp.Version)
+			assert.Equal(t, tt.wantRole, resp.Role)
+			assert.Positive(t, resp.ResponseTime)
+			assert.Equal(t, tt.wantProbe, resp.ProbeResult)
+
+			if tt.wantError != "" {
+				assert.Contains(t, resp.Error, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestMySQLCheckerSSLMode(t *testing.T) {
+	for mode, want := range map[string]string{"": "tls=preferred", "disable": "tls=false", "verify-full": "tls=true"} {
+		c, err := NewMySQLChecker(fmt.Sprintf(`{"address": "db", "ssl_mode": %q}`, mode))
+		require.NoError(t, err)
+		assert.Contains(t, c.dsn, want)
+	}
+}
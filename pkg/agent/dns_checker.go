@@ -0,0 +1,420 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/dns_checker.go
+package agent
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	defaultDNSTimeout  = 5 * time.Second
+	defaultDNSPort     = "53"
+	defaultDNSResolver = "127.0.0.1:53"
+	resolvConfPath     = "/etc/resolv.conf"
+	maxDNSMessageSize  = 65535
+	dnsTCPLengthPrefix = 2
+)
+
+// DNSCheckerConfig describes the query a DNSChecker performs and the
+// assertions made against the answer.
+type DNSCheckerConfig struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type,omitempty"`     // A, AAAA, CNAME, MX, TXT or SRV
+	Resolver   string   `json:"resolver,omitempty"` // host[:port], defaults to the first resolv.conf nameserver
+	Protocol   string   `json:"protocol,omitempty"` // udp or tcp
+	Expected   []string `json:"expected,omitempty"`
+	MinRecords *int     `json:"min_records,omitempty"` // default 1, 0 only requires a NOERROR answer
+	Timeout    Duration `json:"timeout,omitempty"`
+}
+
+// DNSChecker queries a resolver and asserts on the returned records.
+type DNSChecker struct {
+	config DNSCheckerConfig
+	qtype  dnsmessage.Type
+}
+
+// DNSResponse defines the structure of the DNS check result.
+type DNSResponse struct {
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	Resolver     string   `json:"resolver"`
+	Protocol     string   `json:"protocol"`
+	Rcode        string   `json:"rcode,omitempty"`
+	Answers      []string `json:"answers"`
+	RecordCount  int      `json:"record_count"`
+	ResponseTime int64    `json:"response_time"` // in nanoseconds
+	Available    bool     `json:"available"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// NewDNSChecker creates a DNSChecker from the check details, which are
+// either a host name to resolve (A record) or a JSON encoded DNSCheckerConfig.
+func NewDNSChecker(details string) (*DNSChecker, error) {
+	if details == "" {
+		return nil, errDetailsRequiredDNS
+	}
+
+	var cfg DNSCheckerConfig
+
+	if strings.HasPrefix(strings.TrimSpace(details), "{") {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse DNS check details: %w", err)
+		}
+	} else {
+		cfg.Name = strings.TrimSpace(details)
+	}
+
+	if cfg.Name == "" {
+		return nil, errDetailsRequiredDNS
+	}
+
+	cfg.Type = strings.ToUpper(cfg.Type)
+	if cfg.Type == "" {
+		cfg.Type = "A"
+	}
+
+	qtype, ok := dnsRecordType(cfg.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedRecordType, cfg.Type)
+	}
+
+	cfg.Protocol = strings.ToLower(cfg.Protocol)
+
+	switch cfg.Protocol {
+	case "":
+		cfg.Protocol = "udp"
+	case "udp", "tcp":
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedProtocol, cfg.Protocol)
+	}
+
+	if cfg.Resolver == "" {
+		cfg.Resolver = systemResolver()
+	}
+
+	if _, _, err := net.SplitHostPort(cfg.Resolver); err != nil {
+		cfg.Resolver = net.JoinHostPort(cfg.Resolver, defaultDNSPort)
+	}
+
+	if cfg.MinRecords == nil {
+		minRecords := 1
+		cfg.MinRecords = &minRecords
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = Duration(defaultDNSTimeout)
+	}
+
+	return &DNSChecker{config: cfg, qtype: qtype}, nil
+}
+
+// systemResolver returns the first nameserver listed in resolv.conf.
+func systemResolver() string {
+	f, err := os.Open(resolvConfPath)
+	if err != nil {
+		return defaultDNSResolver
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			return net.JoinHostPort(fields[1], defaultDNSPort)
+		}
+	}
+
+	return defaultDNSResolver
+}
+
+// Check queries the resolver and validates the answer.
+func (c *DNSChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout))
+	defer cancel()
+
+	resp := &DNSResponse{
+		Name:     c.config.Name,
+		Type:     c.config.Type,
+		Resolver: c.config.Resolver,
+		Protocol: c.config.Protocol,
+		Answers:  []string{},
+	}
+
+	if err := c.query(ctx, resp); err != nil {
+		resp.Error = err.Error()
+	} else if err := c.validate(resp); err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Available = true
+	}
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal DNS response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+func (c *DNSChecker) query(ctx context.Context, resp *DNSResponse) error {
+	start := time.Now()
+
+	answer, err := exchangeDNS(ctx, c.config.Protocol, c.config.Resolver, c.config.Name, c.qtype)
+	if err == nil && answer.Truncated && c.config.Protocol == "udp" {
+		resp.Protocol = "tcp"
+
+		answer, err = exchangeDNS(ctx, "tcp", c.config.Resolver, c.config.Name, c.qtype)
+	}
+
+	resp.ResponseTime = time.Since(start).Nanoseconds()
+
+	if err != nil {
+		return err
+	}
+
+	resp.Rcode = rcodeName(answer.RCode)
+
+	for _, rr := range answer.Answers {
+		if rr.Header.Type != c.qtype {
+			continue
+		}
+
+		resp.Answers = append(resp.Answers, formatDNSResource(rr.Body))
+	}
+
+	resp.RecordCount = len(resp.Answers)
+
+	return nil
+}
+
+func (c *DNSChecker) validate(resp *DNSResponse) error {
+	if resp.Rcode != rcodeName(dnsmessage.RCodeSuccess) {
+		return fmt.Errorf("%w: %s", errDNSQueryFailed, resp.Rcode)
+	}
+
+	if resp.RecordCount < *c.config.MinRecords {
+		return fmt.Errorf("%w: got %d, expected at least %d", errTooFewRecords, resp.RecordCount, *c.config.MinRecords)
+	}
+
+	for _, expected := range c.config.Expected {
+		if !dnsAnswersContain(resp.Answers, expected) {
+			return fmt.Errorf("%w: %q", errExpectedAnswerMissing, expected)
+		}
+	}
+
+	return nil
+}
+
+// dnsAnswersContain reports whether an expected value matches an answer,
+// either in full or by its target (the last field, e.g. the MX host).
+func dnsAnswersContain(answers []string, expected string) bool {
+	expected = normalizeDNSValue(expected)
+
+	for _, answer := range answers {
+		fields := strings.Fields(answer)
+
+		if normalizeDNSValue(answer) == expected ||
+			(len(fields) > 0 && normalizeDNSValue(fields[len(fields)-1]) == expected) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeDNSValue(v string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(v)), ".")
+}
+
+func dnsRecordType(name string) (dnsmessage.Type, bool) {
+	switch name {
+	case "A":
+		return dnsmessage.TypeA, true
+	case "AAAA":
+		return dnsmessage.TypeAAAA, true
+	case "CNAME":
+		return dnsmessage.TypeCNAME, true
+	case "MX":
+		return dnsmessage.TypeMX, true
+	case "TXT":
+		return dnsmessage.TypeTXT, true
+	case "SRV":
+		return dnsmessage.TypeSRV, true
+	}
+
+	return 0, false
+}
+
+// rcodeName returns the conventional mnemonic for a DNS response code.
+func rcodeName(rcode dnsmessage.RCode) string {
+	switch rcode {
+	case dnsmessage.RCodeSuccess:
+		return "NOERROR"
+	case dnsmessage.RCodeFormatError:
+		return "FORMERR"
+	case dnsmessage.RCodeServerFailure:
+		return "SERVFAIL"
+	case dnsmessage.RCodeNameError:
+		return "NXDOMAIN"
+	case dnsmessage.RCodeNotImplemented:
+		return "NOTIMP"
+	case dnsmessage.RCodeRefused:
+		return "REFUSED"
+	default:
+		return rcode.String()
+	}
+}
+
+func formatDNSResource(body dnsmessage.ResourceBody) string {
+	switch rr := body.(type) {
+	case *dnsmessage.AResource:
+		return net.IP(rr.A[:]).String()
+	case *dnsmessage.AAAAResource:
+		return net.IP(rr.AAAA[:]).String()
+	case *dnsmessage.CNAMEResource:
+		return rr.CNAME.String()
+	case *dnsmessage.MXResource:
+		return fmt.Sprintf("%d %s", rr.Pref, rr.MX.String())
+	case *dnsmessage.TXTResource:
+		return strings.Join(rr.TXT, "")
+	case *dnsmessage.SRVResource:
+		return fmt.Sprintf("%d %d %d %s", rr.Priority, rr.Weight, rr.Port, rr.Target.String())
+	default:
+		return body.GoString()
+	}
+}
+
+// buildDNSQuery packs a recursive query for a single question.
+func buildDNSQuery(id uint16, name string, qtype dnsmessage.Type) ([]byte, error) {
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+
+	qname, err := dnsmessage.NewName(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query name %q: %w", name, err)
+	}
+
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: id, RecursionDesired: true},
+		Questions: []dnsmessage.Question{
+			{Name: qname, Type: qtype, Class: dnsmessage.ClassINET},
+		},
+	}
+
+	return msg.Pack()
+}
+
+func newDNSQueryID() uint16 {
+	var b [2]byte
+
+	_, _ = rand.Read(b[:])
+
+	return binary.BigEndian.Uint16(b[:])
+}
+
+// exchangeDNS sends a single query to the resolver over UDP or TCP and
+// returns the parsed reply.
+func exchangeDNS(ctx context.Context, network, resolver, name string, qtype dnsmessage.Type) (*dnsmessage.Message, error) {
+	id := newDNSQueryID()
+
+	query, err := buildDNSQuery(id, name, qtype)
+	if err != nil {
+		return nil, err
+	}
+
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, network, resolver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to resolver: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	raw, err := roundTripDNS(conn, network, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var answer dnsmessage.Message
+	if err := answer.Unpack(raw); err != nil {
+		return nil, fmt.Errorf("failed to parse DNS reply: %w", err)
+	}
+
+	if answer.ID != id {
+		return nil, fmt.Errorf("%w: reply ID mismatch", errDNSQueryFailed)
+	}
+
+	return &answer, nil
+}
+
+func roundTripDNS(conn net.Conn, network string, query []byte) ([]byte, error) {
+	if network == "tcp" {
+		framed := make([]byte, dnsTCPLengthPrefix, dnsTCPLengthPrefix+len(query))
+		binary.BigEndian.PutUint16(framed, uint16(len(query))) //nolint:gosec // queries are far below 64KiB
+		framed = append(framed, query...)
+
+		if _, err := conn.Write(framed); err != nil {
+			return nil, fmt.Errorf("failed to send DNS query: %w", err)
+		}
+
+		var length [dnsTCPLengthPrefix]byte
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			return nil, fmt.Errorf("failed to read DNS reply: %w", err)
+		}
+
+		raw := make([]byte, binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(conn, raw); err != nil {
+			return nil, fmt.Errorf("failed to read DNS reply: %w", err)
+		}
+
+		return raw, nil
+	}
+
+	if _, err := conn.Write(query); err != nil {
+		return nil, fmt.Errorf("failed to send DNS query: %w", err)
+	}
+
+	raw := make([]byte, maxDNSMessageSize)
+
+	n, err := conn.Read(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read DNS reply: %w", err)
+	}
+
+	return raw[:n], nil
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// startFakeResolver answers A and MX queries for example.com and returns
+// NXDOMAIN for everything else.
+func startFakeResolver(t *testing.T) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil || len(query.Questions) != 1 {
+				continue
+			}
+
+			reply, err := fakeDNSReply(&query).Pack()
+			if err != nil {
+				continue
+			}
+
+			_, _ = conn.WriteTo(reply, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func fakeDNSReply(query *dnsmessage.Message) *dnsmessage.Message {
+	q := query.Questions[0]
+	reply := &dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: query.ID, Response: true, RCode: dnsmessage.RCodeSuccess},
+		Questions: query.Questions,
+	}
+
+	if q.Name.String() != "example.com." {
+		reply.RCode = dnsmessage.RCodeNameError
+
+		return reply
+	}
+
+	hdr := dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: dnsmessage.ClassINET, TTL: 60}
+
+	switch q.Type {
+	case dnsmessage.TypeA:
+		reply.Answers = []dnsmessage.Resource{
+			{Header: hdr, Body: &dnsmessage.AResource{A: [4]byte{192, 0, 2, 1}}},
+			{Header: hdr, Body: &dnsmessage.AResource{A: [4]byte{192, 0, 2, 2}}},
+		}
+	case dnsmessage.TypeMX:
+		reply.Answers = []dnsmessage.Resource{
+			{Header: hdr, Body: &dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mail.example.com.")}},
+		}
+	default:
+	}
+
+	return reply
+}
+
+func TestNewDNSChecker(t *testing.T) {
+	tests := []struct {
+		name         string
+		details      string
+		wantType     string
+		wantResolver string
+		wantError    bool
+	}{
+		{name: "plain name", details: "example.com", wantType: "A"},
+		{
+			name:         "resolver without port",
+			details:      `{"name": "example.com", "type": "mx", "resolver": "10.0.0.53"}`,
+			wantType:     "MX",
+			wantResolver: "10.0.0.53:53",
+		},
+		{name: "empty details", details: "", wantError: true},
+		{name: "unsupported type", details: `{"name": "example.com", "type": "PTR"}`, wantError: true},
+		{name: "unsupported protocol", details: `{"name": "example.com", "protocol": "doh"}`, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewDNSChecker(tt.details)
+			if tt.wantError {
+				require.Error(t, err)
+				assert.Nil(t, c)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, c.config.Type)
+
+			if tt.wantResolver != "" {
+				assert.Equal(t, tt.wantResolver, c.config.Resolver)
+			}
+		})
+	}
+}
+
+func TestDNSCheckerCheck(t *testing.T) {
+	resolver := startFakeResolver(t)
+
+	tests := []struct {
+		name      string
+		details   string
+		wantOK    bool
+		wantRcode string
+		wantCount int
+	}{
+		{
+			name:      "A records",
+			details:   `{"name": "example.com", "resolver": %q, "expected": ["192.0.2.2"]}`,
+			wantOK:    true,
+			wantRcode: "NOERROR",
+			wantCount: 2,
+		},
+		{
+			name:      "MX target match",
+			details:   `{"name": "example.com", "type": "MX", "resolver": %q, "expected": ["mail.example.com"]}`,
+			wantOK:    true,
+			wantRcode: "NOERROR",
+			wantCount: 1,
+		},
+		{
+			name:      "missing expected answer",
+			details:   `{"name": "example.com", "resolver": %q, "expected": ["198.51.100.1"]}`,
+			wantOK:    false,
+			wantRcode: "NOERROR",
+			wantCount: 2,
+		},
+		{
+			name:      "too few records",
+			details:   `{"name": "example.com", "resolver": %q, "min_records": 3}`,
+			wantOK:    false,
+			wantRcode: "NOERROR",
+			wantCount: 2,
+		},
+		{
+			name:      "no records of type",
+			details:   `{"name": "example.com", "type": "TXT", "resolver": %q}`,
+			wantOK:    false,
+			wantRcode: "NOERROR",
+			wantCount: 0,
+		},
+		{
+			name:      "no records allowed",
+			details:   `{"name": "example.com", "type": "TXT", "resolver": %q, "min_records": 0}`,
+			wantOK:    true,
+			wantRcode: "NOERROR",
+			wantCount: 0,
+		},
+		{
+			name:      "NXDOMAIN",
+			details:   `{"name": "missing.example.org", "resolver": %q}`,
+			wantOK:    false,
+			wantRcode: "NXDOMAIN",
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewDNSChecker(fmt.Sprintf(tt.details, resolver))
+			require.NoError(t, err)
+
+			ok, msg := c.Check(context.Background())
+			assert.Equal(t, tt.wantOK, ok, msg)
+
+			var resp DNSResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+			assert.Equal(t, tt.wantRcode, resp.Rcode)
+			assert.Equal(t, tt.wantCount, resp.RecordCount)
+			assert.Positive(t, resp.ResponseTime)
+		})
+	}
+}
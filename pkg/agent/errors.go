@@ -26,4 +26,85 @@ var (
 	errInvalidDetailsFormat = errors.New("invalid details format: expected 'host:port'")
 	errSNMPServiceUnhealthy = errors.New("SNMP service reported unhealthy")
 	errInvalidDuration      = errors.New("invalid duration")
+	errDetailsRequiredHTTP  = errors.New("details field is required for HTTP checks")
+	errInvalidURL           = errors.New("invalid URL: expected an http or https URL")
+	errInvalidBodyRegex     = errors.New("invalid body regex")
+	errTooManyRedirects     = errors.New("too many redirects")
+	errUnexpectedStatus     = errors.New("unexpected status code")
+	errBodyMismatch         = errors.New("response body did not match")
+	errDetailsRequiredTLS   = errors.New("details field is required for TLS checks")
+	errUnsupportedStartTLS  = errors.New("unsupported STARTTLS protocol")
+	errNoCertificates       = errors.New("no certificates found")
+	errCertificateExpiring  = errors.New("certificate expires soon")
+	errUnexpectedReply      = errors.New("unexpected server reply")
+
+	errDetailsRequiredDNS    = errors.New("details field is required for DNS checks")
+	errUnsupportedRecordType = errors.New("unsupported DNS record type")
+	errUnsupportedProtocol   = errors.New("unsupported protocol")
+	errDNSQueryFailed        = errors.New("DNS query failed")
+	errTooFewRecords         = errors.New("too few records")
+	errExpectedAnswerMissing = errors.New("expected answer missing")
+
+	errUnknownProcessStrategy = errors.New("unknown process check strategy")
+	errNoProcessMatcher       = errors.New("procfs checks require a name, cmdline_regex, pid_file or user")
+	errInvalidCmdlineRegex    = errors.New("invalid cmdline regex")
+	errInvalidPIDFile         = errors.New("invalid pid file")
+	errInvalidProcData        = errors.New("invalid procfs data")
+	errProcessNotFound        = errors.New("not enough matching processes")
+
+	errDetailsRequiredExec = errors.New("details field is required for exec checks")
+	errInvalidPluginName   = errors.New("invalid plugin name")
+	errPluginNotFound      = errors.New("plugin not found")
+	errPluginNotExecutable = errors.New("plugin is not an executable file")
+
+	errDetailsRequiredDatabase = errors.New("details field is required for database checks")
+	errUnsupportedSSLMode      = errors.New("unsupported ssl_mode")
+	errInvalidRedisDatabase    = errors.New("redis database must be a number")
+	errInvalidRedisReply       = errors.New("invalid redis reply")
+	errRedisError              = errors.New("redis error")
+
+	errDetailsRequiredUDP  = errors.New("details must be probe:host[:port] or a JSON udp check config")
+	errUnsupportedUDPProbe = errors.New("unsupported udp probe")
+	errUDPPortRequired     = errors.New("a port is required for custom udp payloads")
+	errUnexpectedUDPReply  = errors.New("unexpected udp reply")
+
+	errDetailsRequiredNTP      = errors.New("details field is required for ntp checks")
+	errNoNTPServerReachable    = errors.New("no NTP server reachable")
+	errNTPServerUnsynchronized = errors.New("NTP server is not synchronized")
+	errClockOffset             = errors.New("clock offset too large")
+
+	errDetailsRequiredPrometheus = errors.New("details field is required for prometheus checks")
+	errMetricNameRequired        = errors.New("metric name is required")
+	errInvalidMetricSelector     = errors.New("invalid metric selector")
+	errInvalidMetricsText        = errors.New("invalid metrics exposition")
+	errMissingMetricValue        = errors.New("missing metric value")
+	errInvalidMetricValue        = errors.New("invalid metric value")
+	errUnterminatedLabels        = errors.New("unterminated label set")
+	errMetricAssertionFailed     = errors.New("metric assertion failed")
+
+	errDetailsRequiredLogwatch = errors.New("details must be path:regex or a JSON logwatch check config")
+	errInvalidLogPattern       = errors.New("invalid log pattern")
+	errLogThresholdExceeded    = errors.New("log match threshold exceeded")
+
+	errDetailsRequiredFile = errors.New("details field is required for file checks")
+	errInvalidFileMode     = errors.New("mode must be an octal permission such as 0640")
+	errInvalidSHA256       = errors.New("sha256 must be a hex encoded SHA-256 digest")
+	errFileNotFound        = errors.New("file not found")
+
+	errDetailsRequiredContainer = errors.New("details field is required for container checks")
+	errContainerNotHealthy      = errors.New("containers not running or unhealthy")
+	errDockerAPI                = errors.New("docker API error")
+
+	errDetailsRequiredBanner     = errors.New("details field is required for banner checks")
+	errUnsupportedBannerProtocol = errors.New("unsupported banner protocol")
+	errBannerPortRequired        = errors.New("a port is required for generic banner checks")
+	errInvalidBannerRegex        = errors.New("invalid banner regex")
+	errBannerMismatch            = errors.New("unexpected banner")
+
+	errDetailsRequiredGRPCHealth = errors.New("details field is required for grpc_health checks")
+	errUnsupportedGRPCTLSMode    = errors.New("tls must be none, tls or mtls")
+	errClientCertRequired        = errors.New("mtls requires cert_file and key_file")
+	errServiceNotServing         = errors.New("service is not serving")
+
+	errCheckTimeout = errors.New("check timed out")
 )
@@ -0,0 +1,385 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/exec_checker.go
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultPluginsDir  = "/etc/serviceradar/plugins"
+	defaultExecTimeout = 30 * time.Second
+	maxExecOutputBytes = 64 * 1024
+	executableModeBits = 0111
+	execWaitDelay      = time.Second
+
+	// Fields of a perfdata item after splitting on ";".
+	perfFieldWarning  = 1
+	perfFieldCritical = 2
+	perfFieldMin      = 3
+	perfFieldMax      = 4
+
+	// Nagios plugin exit codes.
+	execStatusOK       = 0
+	execStatusWarning  = 1
+	execStatusCritical = 2
+	execStatusUnknown  = 3
+)
+
+// ExecCheckerConfig selects the plugin to run and its arguments. Command is
+// the file name of an executable in the agent's plugins directory.
+type ExecCheckerConfig struct {
+	Command      string   `json:"command"`
+	Args         []string `json:"args,omitempty"`
+	AllowWarning bool     `json:"allow_warning,omitempty"` // report WARNING as available
+	Timeout      Duration `json:"timeout,omitempty"`
+}
+
+// ExecChecker runs a Nagios/Icinga compatible plugin without a shell.
+type ExecChecker struct {
+	config ExecCheckerConfig
+	path   string
+}
+
+// PerfData is a single performance data item from plugin output, in the
+// form 'label'=value[UOM];[warn];[crit];[min];[max].
+type PerfData struct {
+	Label    string   `json:"label"`
+	Value    float64  `json:"value"`
+	UOM      string   `json:"uom,omitempty"`
+	Warning  string   `json:"warning,omitempty"`
+	Critical string   `json:"critical,omitempty"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+}
+
+// ExecResponse defines the structure of the exec check result.
+type ExecResponse struct {
+	Command      string     `json:"command"`
+	ExitCode     int        `json:"exit_code"`
+	Status       string     `json:"status"`
+	Output       string     `json:"output"`
+	LongOutput   string     `json:"long_output,omitempty"`
+	Metrics      []PerfData `json:"metrics"`
+	ResponseTime int64      `json:"response_time"`
+	Truncated    bool       `json:"truncated,omitempty"` // output exceeded the limit and was cut
+	Available    bool       `json:"available"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// NewExecChecker creates an ExecChecker for a plugin in pluginsDir. The
+// details are either a command line ("check_load -w 5 -c 10") or a JSON
+// encoded ExecCheckerConfig.
+func NewExecChecker(pluginsDir, details string) (*ExecChecker, error) {
+	details = strings.TrimSpace(details)
+	if details == "" {
+		return nil, errDetailsRequiredExec
+	}
+
+	var cfg ExecCheckerConfig
+
+	if strings.HasPrefix(details, "{") {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse exec check details: %w", err)
+		}
+	} else {
+		fields := strings.Fields(details)
+		cfg.Command, cfg.Args = fields[0], fields[1:]
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = Duration(defaultExecTimeout)
+	}
+
+	path, err := resolvePlugin(pluginsDir, cfg.Command)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExecChecker{config: cfg, path: path}, nil
+}
+
+// resolvePlugin validates the command name the same way process names are
+// validated and returns the path of the executable inside pluginsDir.
+func resolvePlugin(pluginsDir, command string) (string, error) {
+	if pluginsDir == "" {
+		pluginsDir = defaultPluginsDir
+	}
+
+	if err := validateName(command, "plugin", errInvalidPluginName); err != nil {
+		return "", err
+	}
+
+	if strings.HasPrefix(command, ".") {
+		return "", fmt.Errorf("%w: %s", errInvalidPluginName, command)
+	}
+
+	path := filepath.Join(pluginsDir, command)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errPluginNotFound, err)
+	}
+
+	if !info.Mode().IsRegular() || info.Mode().Perm()&executableModeBits == 0 {
+		return "", fmt.Errorf("%w: %s", errPluginNotExecutable, path)
+	}
+
+	return path, nil
+}
+
+// Check runs the plugin and maps its exit code to a Nagios state.
+func (c *ExecChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout))
+	defer cancel()
+
+	resp := &ExecResponse{Command: c.config.Command, Metrics: []PerfData{}}
+
+	start := time.Now()
+
+	// The command is resolved inside the plugins directory and run without a shell.
+	cmd := exec.CommandContext(ctx, c.path, c.config.Args...) //nolint:gosec // validated in resolvePlugin
+
+	// Run the plugin in its own process group so a timeout also kills anything it spawned.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = execWaitDelay
+
+	output := &cappedWriter{limit: maxExecOutputBytes}
+	cmd.Stdout = output
+
+	err := cmd.Run()
+
+	resp.ResponseTime = time.Since(start).Nanoseconds()
+	resp.Truncated = output.truncated
+
+	resp.Output, resp.LongOutput, resp.Metrics = parsePluginOutput(output.buf.String())
+	resp.ExitCode = execExitCode(ctx, err, resp)
+	resp.Status = execStatusName(resp.ExitCode)
+	resp.Available = resp.ExitCode == execStatusOK || (c.config.AllowWarning && resp.ExitCode == execStatusWarning)
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal exec response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+// cappedWriter keeps the first limit bytes written to it and discards the
+// rest, so a plugin flooding stdout cannot grow the agent's memory.
+type cappedWriter struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (w *cappedWriter) Write(p []byte) (int, error) {
+	n := len(p)
+
+	if room := w.limit - w.buf.Len(); n > room {
+		w.truncated = true
+		p = p[:room]
+	}
+
+	w.buf.Write(p)
+
+	return n, nil
+}
+
+// execExitCode returns the plugin exit code. Timeouts are CRITICAL and
+// plugins that cannot be run or exit with an unexpected code are UNKNOWN.
+func execExitCode(ctx context.Context, err error, resp *ExecResponse) int {
+	if ctx.Err() != nil {
+		resp.Error = fmt.Sprintf("plugin timed out: %v", ctx.Err())
+
+		return execStatusCritical
+	}
+
+	if err == nil {
+		return execStatusOK
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		resp.Error = err.Error()
+
+		return execStatusUnknown
+	}
+
+	code := exitErr.ExitCode()
+	if code < execStatusOK || code > execStatusUnknown {
+		resp.Error = fmt.Sprintf("unexpected exit code %d", code)
+
+		return execStatusUnknown
+	}
+
+	return code
+}
+
+func execStatusName(code int) string {
+	switch code {
+	case execStatusOK:
+		return "OK"
+	case execStatusWarning:
+		return "WARNING"
+	case execStatusCritical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// parsePluginOutput splits plugin output into the status text, the long
+// output and the performance data. Perfdata follows a "|" on the first line
+// and, per the plugin API, on the first long output line containing one
+// together with every line after it.
+func parsePluginOutput(output string) (text, longOutput string, metrics []PerfData) {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+
+	text, perf, _ := strings.Cut(lines[0], "|")
+	perfParts := []string{perf}
+
+	var longLines []string
+
+	for i, line := range lines[1:] {
+		if before, after, found := strings.Cut(line, "|"); found {
+			longLines = append(longLines, before)
+			perfParts = append(perfParts, after)
+			perfParts = append(perfParts, lines[i+2:]...)
+
+			break
+		}
+
+		longLines = append(longLines, line)
+	}
+
+	return strings.TrimSpace(text),
+		strings.TrimSpace(strings.Join(longLines, "\n")),
+		parsePerfData(strings.Join(perfParts, " "))
+}
+
+// parsePerfData parses space separated perfdata items. Labels may be single
+// quoted to contain spaces; items that do not parse are skipped.
+func parsePerfData(perf string) []PerfData {
+	metrics := []PerfData{}
+
+	for _, item := range splitPerfData(perf) {
+		label, values, found := strings.Cut(item, "=")
+		if !found {
+			continue
+		}
+
+		fields := strings.Split(values, ";")
+
+		value, uom := splitPerfValue(fields[0])
+
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+
+		field := func(i int) string {
+			if i < len(fields) {
+				return fields[i]
+			}
+
+			return ""
+		}
+
+		metrics = append(metrics, PerfData{
+			Label:    strings.ReplaceAll(strings.Trim(label, "'"), "''", "'"),
+			Value:    v,
+			UOM:      uom,
+			Warning:  field(perfFieldWarning),
+			Critical: field(perfFieldCritical),
+			Min:      parseOptionalFloat(field(perfFieldMin)),
+			Max:      parseOptionalFloat(field(perfFieldMax)),
+		})
+	}
+
+	return metrics
+}
+
+// splitPerfData splits on whitespace outside single quoted labels.
+func splitPerfData(perf string) []string {
+	var (
+		items   []string
+		current strings.Builder
+		quoted  bool
+	)
+
+	for _, r := range perf {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t') && !quoted:
+			if current.Len() > 0 {
+				items = append(items, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if current.Len() > 0 {
+		items = append(items, current.String())
+	}
+
+	return items
+}
+
+// splitPerfValue separates the numeric value from its unit of measurement.
+func splitPerfValue(s string) (value, uom string) {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+'
+	})
+	if i < 0 {
+		return s, ""
+	}
+
+	return s[:i], s[i:]
+}
+
+func parseOptionalFloat(s string) *float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &v
+}
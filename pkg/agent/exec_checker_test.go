@@ -0,0 +1,184 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePlugins creates a plugins directory with small shell script plugins.
+func writePlugins(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	plugins := map[string]string{
+		"check_ok":      "echo 'LOAD OK - load average: 0.10 | load1=0.10;5;10;0; load5=0.20;4;8;0;'",
+		"check_warn":    "echo 'DISK WARNING - 85% used|/=85%;80;90;0;100'; exit 1",
+		"check_crit":    "echo 'PROCS CRITICAL'; exit 2",
+		"check_unknown": "echo 'UNKNOWN - bad arguments'; exit 3",
+		"check_weird":   "exit 7",
+		"check_args":    `echo "ARGS OK - $1 $2"`,
+		"check_slow":    "sleep 5",
+		"check_flood":   "echo 'FLOOD OK'; yes | head -c 1048576",
+	}
+
+	for name, body := range plugins {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+body+"\n"), 0700))
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "not_executable"), []byte("#!/bin/sh\n"), 0600))
+
+	return dir
+}
+
+func TestNewExecChecker(t *testing.T) {
+	dir := writePlugins(t)
+
+	tests := []struct {
+		name      string
+		details   string
+		wantArgs  []string
+		wantError error
+	}{
+		{name: "command line", details: "check_args -w 5", wantArgs: []string{"-w", "5"}},
+		{name: "json", details: `{"command": "check_args", "args": ["a b"]}`, wantArgs: []string{"a b"}},
+		{name: "empty details", details: "", wantError: errDetailsRequiredExec},
+		{name: "path traversal", details: "../check_ok", wantError: errInvalidCharacters},
+		{name: "absolute path", details: "/bin/sh -c id", wantError: errInvalidCharacters},
+		{name: "shell syntax", details: `{"command": "check_ok;id"}`, wantError: errInvalidCharacters},
+		{name: "dot name", details: "..", wantError: errInvalidPluginName},
+		{name: "missing plugin", details: "check_missing", wantError: errPluginNotFound},
+		{name: "not executable", details: "not_executable", wantError: errPluginNotExecutable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewExecChecker(dir, tt.details)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				assert.Nil(t, c)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantArgs, c.config.Args)
+			assert.Equal(t, filepath.Join(dir, "check_args"), c.path)
+		})
+	}
+}
+
+func TestExecCheckerCheck(t *testing.T) {
+	dir := writePlugins(t)
+
+	tests := []struct {
+		name        string
+		details     string
+		wantOK      bool
+		wantStatus  string
+		wantCode    int
+		wantOutput  string
+		wantMetrics int
+	}{
+		{name: "ok", details: "check_ok", wantOK: true, wantStatus: "OK", wantCode: 0,
+			wantOutput: "LOAD OK - load average: 0.10", wantMetrics: 2},
+		{name: "warning", details: "check_warn", wantOK: false, wantStatus: "WARNING", wantCode: 1,
+			wantOutput: "DISK WARNING - 85% used", wantMetrics: 1},
+		{name: "warning allowed", details: `{"command": "check_warn", "allow_warning": true}`, wantOK: true,
+			wantStatus: "WARNING", wantCode: 1, wantOutput: "DISK WARNING - 85% used", wantMetrics: 1},
+		{name: "critical", details: "check_crit", wantStatus: "CRITICAL", wantCode: 2, wantOutput: "PROCS CRITICAL"},
+		{name: "unknown", details: "check_unknown", wantStatus: "UNKNOWN", wantCode: 3, wantOutput: "UNKNOWN - bad arguments"},
+		{name: "unexpected exit code", details: "check_weird", wantStatus: "UNKNOWN", wantCode: 3},
+		{name: "arguments", details: "check_args $(id) ;ls", wantOK: true, wantStatus: "OK",
+			wantOutput: "ARGS OK - $(id) ;ls"},
+		{name: "timeout", details: `{"command": "check_slow", "timeout": "100ms"}`, wantStatus: "CRITICAL", wantCode: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewExecChecker(dir, tt.details)
+			require.NoError(t, err)
+
+			ok, msg := c.Check(context.Background())
+			assert.Equal(t, tt.wantOK, ok, msg)
+
+			var resp ExecResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+			assert.Equal(t, tt.wantStatus, resp.Status)
+			assert.Equal(t, tt.wantCode, resp.ExitCode)
+			assert.Equal(t, tt.wantOutput, resp.Output)
+			assert.Len(t, resp.Metrics, tt.wantMetrics)
+		})
+	}
+}
+
+func TestExecCheckerOutputLimit(t *testing.T) {
+	c, err := NewExecChecker(writePlugins(t), "check_flood")
+	require.NoError(t, err)
+
+	ok, msg := c.Check(context.Background())
+	assert.True(t, ok, msg)
+
+	var resp ExecResponse
+	require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+	assert.True(t, resp.Truncated)
+	assert.Equal(t, "FLOOD OK", resp.Output)
+	assert.Less(t, len(resp.LongOutput), maxExecOutputBytes)
+}
+
+func TestParsePluginOutput(t *testing.T) {
+	output := "DISK OK - free space: / 3326 MB (56%); | /=2643MB;5948;5958;0;5968\n" +
+		"/ 15272 MB (77%);\n" +
+		"/boot 68 MB (69%);\n" +
+		"/home 69357 MB (27%);| /boot=68MB;88;93;0;98\n" +
+		"/home=69357MB;253404;253409;0;253414 'disk time'=12.5ms;;;;\n"
+
+	text, longOutput, metrics := parsePluginOutput(output)
+
+	assert.Equal(t, "DISK OK - free space: / 3326 MB (56%);", text)
+	assert.Equal(t, "/ 15272 MB (77%);\n/boot 68 MB (69%);\n/home 69357 MB (27%);", longOutput)
+	require.Len(t, metrics, 4)
+
+	assert.Equal(t, "/", metrics[0].Label)
+	assert.InDelta(t, 2643.0, metrics[0].Value, 0.001)
+	assert.Equal(t, "MB", metrics[0].UOM)
+	assert.Equal(t, "5948", metrics[0].Warning)
+	assert.Equal(t, "5958", metrics[0].Critical)
+	require.NotNil(t, metrics[0].Min)
+	require.NotNil(t, metrics[0].Max)
+	assert.InDelta(t, 5968.0, *metrics[0].Max, 0.001)
+
+	assert.Equal(t, "/home", metrics[2].Label)
+	assert.Equal(t, "disk time", metrics[3].Label)
+	assert.Equal(t, "ms", metrics[3].UOM)
+	assert.Empty(t, metrics[3].Warning)
+	assert.Nil(t, metrics[3].Min)
+}
+
+func TestValidateName(t *testing.T) {
+	require.NoError(t, validateName("check_http", "plugin", errInvalidPluginName))
+	require.ErrorIs(t, validateName(string(make([]byte, maxProcessNameLength+1)), "plugin", errInvalidPluginName),
+		errInvalidPluginName)
+	require.ErrorIs(t, validateName("check http", "plugin", errInvalidPluginName), errInvalidCharacters)
+}
@@ -21,7 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"strings"
+	"net"
 	"sync"
 	"time"
 
@@ -61,7 +61,9 @@ type ExternalChecker struct {
 }
 
 // NewExternalChecker creates a new checker that connects to an external process.
-func NewExternalChecker(ctx context.Context, serviceName, serviceType, address string) (*ExternalChecker, error) {
+// A nil security config connects without transport security.
+func NewExternalChecker(
+	ctx context.Context, serviceName, serviceType, address string, security *models.SecurityConfig) (*ExternalChecker, error) {
 	log.Printf("Creating new external checker name=%s type=%s at %s", serviceName, serviceType, address)
 
 	clientCfg := grpc.ClientConfig{
@@ -69,15 +71,7 @@ func NewExternalChecker(ctx context.Context, serviceName, serviceType, address s
 		MaxRetries: maxRetries,
 	}
 
-	security := models.SecurityConfig{
-		Mode:       "mtls",
-		CertDir:    "/etc/serviceradar/certs", // TODO: Make configurable
-		ServerName: strings.Split(address, ":")[0],
-		Role:       "agent",
-	}
-
-	provider, err := grpc.NewSecurityProvider(ctx, &security)
-
+	provider, err := grpc.NewSecurityProvider(ctx, security)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create security provider: %w", err)
 	}
@@ -226,3 +220,34 @@ func (e *ExternalChecker) Close() error {
 
 	return nil
 }
+
+// checkerSecurity returns the security settings for connecting to a checker
+// at address: the checker's own settings when configured, otherwise the
+// agent's. The checker's host is verified unless the checker's settings name
+// another server (the agent's server name identifies the agent itself), and
+// the client side always acts as the agent.
+func checkerSecurity(conf *CheckerConfig, agent *models.SecurityConfig, address string) *models.SecurityConfig {
+	var security models.SecurityConfig
+
+	switch {
+	case conf != nil && conf.Security != nil:
+		security = *conf.Security
+	case agent != nil:
+		security = *agent
+		security.ServerName = ""
+	default:
+		return nil
+	}
+
+	if security.ServerName == "" {
+		security.ServerName = address
+
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			security.ServerName = host
+		}
+	}
+
+	security.Role = models.RoleAgent
+
+	return &security
+}
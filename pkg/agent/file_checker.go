@@ -0,0 +1,267 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/file_checker.go
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	fileTypeRegular   = "file"
+	fileTypeDirectory = "directory"
+	fileTypeOther     = "other"
+
+	permissionBits = 0o7777
+	hashChunkBytes = 1 << 20
+)
+
+// FileCheckerConfig describes the attributes a file must have. Zero values
+// disable the corresponding assertion. Owner and Group accept a name or a
+// numeric id, Mode is an octal permission string such as "0640".
+type FileCheckerConfig struct {
+	Path    string   `json:"path"`
+	MaxAge  Duration `json:"max_age,omitempty"`
+	MinSize int64    `json:"min_size,omitempty"`
+	MaxSize int64    `json:"max_size,omitempty"`
+	Owner   string   `json:"owner,omitempty"`
+	Group   string   `json:"group,omitempty"`
+	Mode    string   `json:"mode,omitempty"`
+	SHA256  string   `json:"sha256,omitempty"` // expected hex digest
+	Hash    bool     `json:"hash,omitempty"`   // report the digest without asserting it
+}
+
+// FileChecker verifies the existence, freshness and integrity of a file.
+type FileChecker struct {
+	config FileCheckerConfig
+	mode   uint32
+}
+
+// FileResponse defines the structure of the file check result.
+type FileResponse struct {
+	Path         string   `json:"path"`
+	Exists       bool     `json:"exists"`
+	Type         string   `json:"type,omitempty"`
+	Size         int64    `json:"size"`
+	Mode         string   `json:"mode,omitempty"`
+	Owner        string   `json:"owner,omitempty"`
+	UID          uint32   `json:"uid"`
+	Group        string   `json:"group,omitempty"`
+	GID          uint32   `json:"gid"`
+	ModTime      int64    `json:"mod_time,omitempty"` // Unix seconds
+	Age          int64    `json:"age"`                // in nanoseconds
+	SHA256       string   `json:"sha256,omitempty"`
+	Violations   []string `json:"violations,omitempty"`
+	ResponseTime int64    `json:"response_time"`
+	Available    bool     `json:"available"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// NewFileChecker creates a FileChecker from the check details, which are
+// either a path (existence only) or a JSON encoded FileCheckerConfig.
+func NewFileChecker(details string) (*FileChecker, error) {
+	details = strings.TrimSpace(details)
+
+	var cfg FileCheckerConfig
+
+	if strings.HasPrefix(details, "{") {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse file check details: %w", err)
+		}
+	} else {
+		cfg.Path = details
+	}
+
+	if cfg.Path == "" {
+		return nil, errDetailsRequiredFile
+	}
+
+	c := &FileChecker{config: cfg}
+
+	if cfg.Mode != "" {
+		mode, err := strconv.ParseUint(cfg.Mode, 8, 32)
+		if err != nil || mode > permissionBits {
+			return nil, fmt.Errorf("%w: %q", errInvalidFileMode, cfg.Mode)
+		}
+
+		c.mode = uint32(mode)
+	}
+
+	if cfg.SHA256 != "" {
+		digest, err := hex.DecodeString(cfg.SHA256)
+		if err != nil || len(digest) != sha256.Size {
+			return nil, fmt.Errorf("%w: %q", errInvalidSHA256, cfg.SHA256)
+		}
+
+		c.config.SHA256 = hex.EncodeToString(digest)
+	}
+
+	return c, nil
+}
+
+// Check stats the file, hashes it when requested and compares every
+// attribute to the configuration.
+func (c *FileChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	resp := &FileResponse{Path: c.config.Path}
+
+	start := time.Now()
+
+	if err := c.inspect(ctx, resp); err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Violations = c.violations(resp)
+		resp.Available = len(resp.Violations) == 0
+	}
+
+	resp.ResponseTime = time.Since(start).Nanoseconds()
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal file response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+func (c *FileChecker) inspect(ctx context.Context, resp *FileResponse) error {
+	info, err := os.Stat(c.config.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", errFileNotFound, c.config.Path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	resp.Exists = true
+	resp.Size = info.Size()
+	resp.ModTime = info.ModTime().Unix()
+	resp.Age = time.Since(info.ModTime()).Nanoseconds()
+
+	switch {
+	case info.Mode().IsRegular():
+		resp.Type = fileTypeRegular
+	case info.IsDir():
+		resp.Type = fileTypeDirectory
+	default:
+		resp.Type = fileTypeOther
+	}
+
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		resp.Mode = fmt.Sprintf("%04o", stat.Mode&permissionBits)
+		resp.UID, resp.GID = stat.Uid, stat.Gid
+
+		if u, err := user.LookupId(strconv.FormatUint(uint64(stat.Uid), 10)); err == nil {
+			resp.Owner = u.Username
+		}
+
+		if g, err := user.LookupGroupId(strconv.FormatUint(uint64(stat.Gid), 10)); err == nil {
+			resp.Group = g.Name
+		}
+	}
+
+	if (c.config.SHA256 != "" || c.config.Hash) && resp.Type == fileTypeRegular {
+		if resp.SHA256, err = hashFile(ctx, c.config.Path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *FileChecker) violations(resp *FileResponse) []string {
+	var violations []string
+
+	if c.config.MaxAge > 0 && resp.Age > time.Duration(c.config.MaxAge).Nanoseconds() {
+		violations = append(violations, fmt.Sprintf("modified %v ago, more than %v",
+			time.Duration(resp.Age).Round(time.Second), time.Duration(c.config.MaxAge)))
+	}
+
+	if c.config.MinSize > 0 && resp.Size < c.config.MinSize {
+		violations = append(violations, fmt.Sprintf("size %d is below %d bytes", resp.Size, c.config.MinSize))
+	}
+
+	if c.config.MaxSize > 0 && resp.Size > c.config.MaxSize {
+		violations = append(violations, fmt.Sprintf("size %d exceeds %d bytes", resp.Size, c.config.MaxSize))
+	}
+
+	if c.config.Owner != "" && !matchesID(c.config.Owner, resp.Owner, resp.UID) {
+		violations = append(violations, fmt.Sprintf("owner %s (%d) is not %s", resp.Owner, resp.UID, c.config.Owner))
+	}
+
+	if c.config.Group != "" && !matchesID(c.config.Group, resp.Group, resp.GID) {
+		violations = append(violations, fmt.Sprintf("group %s (%d) is not %s", resp.Group, resp.GID, c.config.Group))
+	}
+
+	if c.config.Mode != "" && resp.Mode != fmt.Sprintf("%04o", c.mode) {
+		violations = append(violations, fmt.Sprintf("mode %s is not %04o", resp.Mode, c.mode))
+	}
+
+	if c.config.SHA256 != "" && resp.SHA256 != c.config.SHA256 {
+		violations = append(violations, "sha256 does not match the baseline")
+	}
+
+	return violations
+}
+
+// matchesID compares an owner or group given as a name or a numeric id.
+func matchesID(want, name string, id uint32) bool {
+	return want == name || want == strconv.FormatUint(uint64(id), 10)
+}
+
+// hashFile computes the SHA-256 digest of a file, stopping when the context
+// is canceled so a large file cannot outlive the check.
+func hashFile(ctx context.Context, path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", fmt.Errorf("failed to hash file: %w", err)
+		}
+
+		_, err := io.CopyN(h, f, hashChunkBytes)
+		if errors.Is(err, io.EOF) {
+			return hex.EncodeToString(h.Sum(nil)), nil
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("failed to hash file: %w", err)
+		}
+	}
+}
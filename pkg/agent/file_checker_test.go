@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFileChecker(t *testing.T) {
+	c, err := NewFileChecker("/var/backups/db.dump")
+	require.NoError(t, err)
+	assert.Equal(t, "/var/backups/db.dump", c.config.Path)
+
+	_, err = NewFileChecker("")
+	require.ErrorIs(t, err, errDetailsRequiredFile)
+
+	_, err = NewFileChecker(`{"path": "/etc/passwd", "mode": "0986"}`)
+	require.ErrorIs(t, err, errInvalidFileMode)
+
+	_, err = NewFileChecker(`{"path": "/etc/passwd", "sha256": "abc"}`)
+	require.ErrorIs(t, err, errInvalidSHA256)
+}
+
+func TestFileCheckerCheck(t *testing.T) {
+	content := []byte("backup contents\n")
+	digest := sha256.Sum256(content)
+
+	path := filepath.Join(t.TempDir(), "db.dump")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	require.NoError(t, os.Chmod(path, 0o640))
+
+	modTime := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+
+	uid := os.Getuid()
+
+	tests := []struct {
+		name           string
+		config         string
+		wantOK         bool
+		wantViolations int
+	}{
+		{"exists", `{}`, true, 0},
+		{"fresh enough", `{"max_age": "3h"}`, true, 0},
+		{"stale", `{"max_age": "1h"}`, false, 1},
+		{"size bounds", `{"min_size": 10, "max_size": 100}`, true, 0},
+		{"too small", `{"min_size": 1024}`, false, 1},
+		{"too large", `{"max_size": 4}`, false, 1},
+		{"owner and mode", fmt.Sprintf(`{"owner": "%d", "mode": "640"}`, uid), true, 0},
+		{"wrong owner and mode", fmt.Sprintf(`{"owner": "%d", "mode": "0600"}`, uid+1), false, 2},
+		{"matching sha256", fmt.Sprintf(`{"sha256": %q}`, hex.EncodeToString(digest[:])), true, 0},
+		{"changed sha256", fmt.Sprintf(`{"sha256": %q}`, hex.EncodeToString(make([]byte, sha256.Size))), false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.config), &cfg))
+
+			cfg["path"] = path
+
+			details, err := json.Marshal(cfg)
+			require.NoError(t, err)
+
+			c, err := NewFileChecker(string(details))
+			require.NoError(t, err)
+
+			ok, msg := c.Check(context.Background())
+
+			var resp FileResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+
+			assert.Equal(t, tt.wantOK, ok, msg)
+			assert.Len(t, resp.Violations, tt.wantViolations, msg)
+			assert.True(t, resp.Exists)
+			assert.Equal(t, fileTypeRegular, resp.Type)
+			assert.Equal(t, int64(len(content)), resp.Size)
+			assert.Equal(t, "0640", resp.Mode)
+			assert.Equal(t, uint32(uid), resp.UID) //nolint:gosec // uids are never negative
+			assert.Equal(t, modTime.Unix(), resp.ModTime)
+			assert.InDelta(t, 2*time.Hour, time.Duration(resp.Age), float64(time.Minute))
+		})
+	}
+}
+
+func TestFileCheckerHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.conf")
+	require.NoError(t, os.WriteFile(path, []byte("listen 80\n"), 0o600))
+
+	c, err := NewFileChecker(fmt.Sprintf(`{"path": %q, "hash": true}`, path))
+	require.NoError(t, err)
+
+	ok, msg := c.Check(context.Background())
+	require.True(t, ok, msg)
+
+	var resp FileResponse
+	require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+
+	digest := sha256.Sum256([]byte("listen 80\n"))
+	assert.Equal(t, hex.EncodeToString(digest[:]), resp.SHA256)
+}
+
+func TestFileCheckerMissing(t *testing.T) {
+	c, err := NewFileChecker(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+
+	ok, msg := c.Check(context.Background())
+	assert.False(t, ok)
+
+	var resp FileResponse
+	require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+	assert.False(t, resp.Exists)
+	assert.Contains(t, resp.Error, errFileNotFound.Error())
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/grpc_health_checker.go
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultGRPCHealthTimeout = 5 * time.Second
+
+	grpcTLSNone = "none"
+	grpcTLS     = "tls"
+	grpcMTLS    = "mtls"
+)
+
+// GRPCHealthCheckerConfig describes a call to the standard gRPC health
+// service. An empty Service asks for the health of the whole server. TLS is
+// none, tls or mtls; CAFile defaults to the system roots and mtls also
+// requires CertFile and KeyFile.
+type GRPCHealthCheckerConfig struct {
+	Address            string   `json:"address"`
+	Service            string   `json:"service,omitempty"`
+	TLS                string   `json:"tls,omitempty"`
+	CAFile             string   `json:"ca_file,omitempty"`
+	CertFile           string   `json:"cert_file,omitempty"`
+	KeyFile            string   `json:"key_file,omitempty"`
+	ServerName         string   `json:"server_name,omitempty"`
+	InsecureSkipVerify bool     `json:"insecure_skip_verify,omitempty"`
+	Timeout            Duration `json:"timeout,omitempty"`
+}
+
+// GRPCHealthChecker calls grpc.health.v1.Health/Check on any gRPC server,
+// unlike the grpc checker type, which talks to ServiceRadar external checkers.
+type GRPCHealthChecker struct {
+	config GRPCHealthCheckerConfig
+	conn   *grpc.ClientConn
+	health grpc_health_v1.HealthClient
+}
+
+// GRPCHealthResponse defines the structure of the gRPC health check result.
+type GRPCHealthResponse struct {
+	Address      string `json:"address"`
+	Service      string `json:"service,omitempty"`
+	Status       string `json:"status,omitempty"`
+	ResponseTime int64  `json:"response_time"`
+	Available    bool   `json:"available"`
+	Error        string `json:"error,omitempty"`
+}
+
+// NewGRPCHealthChecker creates a GRPCHealthChecker from the check details,
+// which are either "host:port[/service]" or a JSON encoded GRPCHealthCheckerConfig.
+// The connection is established lazily and reused across checks.
+func NewGRPCHealthChecker(details string) (*GRPCHealthChecker, error) {
+	details = strings.TrimSpace(details)
+	if details == "" {
+		return nil, errDetailsRequiredGRPCHealth
+	}
+
+	var cfg GRPCHealthCheckerConfig
+
+	if strings.HasPrefix(details, "{") {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse grpc health check details: %w", err)
+		}
+	} else {
+		cfg.Address, cfg.Service, _ = strings.Cut(details, "/")
+	}
+
+	if cfg.Address == "" {
+		return nil, errDetailsRequiredGRPCHealth
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = Duration(defaultGRPCHealthTimeout)
+	}
+
+	creds, err := grpcHealthCredentials(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(cfg.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create grpc client for %s: %w", cfg.Address, err)
+	}
+
+	return &GRPCHealthChecker{
+		config: cfg,
+		conn:   conn,
+		health: grpc_health_v1.NewHealthClient(conn),
+	}, nil
+}
+
+func grpcHealthCredentials(cfg *GRPCHealthCheckerConfig) (credentials.TransportCredentials, error) {
+	cfg.TLS = strings.ToLower(cfg.TLS)
+
+	switch cfg.TLS {
+	case "", grpcTLSNone:
+		cfg.TLS = grpcTLSNone
+
+		return insecure.NewCredentials(), nil
+	case grpcTLS, grpcMTLS:
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedGRPCTLSMode, cfg.TLS)
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // opt-in for self-signed endpoints
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %s", errNoCertificates, cfg.CAFile)
+		}
+	}
+
+	if cfg.TLS == grpcMTLS {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, errClientCertRequired
+		}
+
+		if _, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile); err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+
+		// Reloaded on every handshake so renewed certificates are picked up.
+		certFile, keyFile := cfg.CertFile, cfg.KeyFile
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+
+			return &cert, err
+		}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// Check calls the health service and passes when it reports SERVING.
+func (c *GRPCHealthChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout))
+	defer cancel()
+
+	resp := &GRPCHealthResponse{Address: c.config.Address, Service: c.config.Service}
+
+	start := time.Now()
+
+	result, err := c.health.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: c.config.Service})
+
+	resp.ResponseTime = time.Since(start).Nanoseconds()
+
+	switch {
+	case err != nil:
+		resp.Status = status.Code(err).String()
+		resp.Error = fmt.Sprintf("health check failed: %v", status.Convert(err).Message())
+	case result.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING:
+		resp.Status = result.GetStatus().String()
+		resp.Error = fmt.Sprintf("%v: %s", errServiceNotServing, resp.Status)
+	default:
+		resp.Status = result.GetStatus().String()
+		resp.Available = true
+	}
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal grpc health response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+// Close releases the connection.
+func (c *GRPCHealthChecker) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,240 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// startHealthServer serves the standard health service with payments.v1
+// serving and search.v1 not serving.
+func startHealthServer(t *testing.T, opts ...grpc.ServerOption) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("payments.v1.Payments", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("search.v1.Search", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	server := grpc.NewServer(opts...)
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+
+	go func() { _ = server.Serve(ln) }()
+
+	t.Cleanup(server.Stop)
+
+	return ln.Addr().String()
+}
+
+// writeTestPKI writes a CA, a server certificate for 127.0.0.1 and a client
+// certificate, and returns their paths.
+func writeTestPKI(t *testing.T) (caFile, serverCert, serverKey, clientCert, clientKey string) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, ca, ca, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	caFile = filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0o600))
+
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+
+		certPath, keyPath := filepath.Join(dir, name+".pem"), filepath.Join(dir, name+"-key.pem")
+		require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+		require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+		return certPath, keyPath
+	}
+
+	serverCert, serverKey = issue("server", 2, x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey = issue("client", 3, x509.ExtKeyUsageClientAuth)
+
+	return caFile, serverCert, serverKey, clientCert, clientKey
+}
+
+func checkGRPCHealth(t *testing.T, cfg GRPCHealthCheckerConfig) (bool, GRPCHealthResponse) {
+	t.Helper()
+
+	cfg.Timeout = Duration(2 * time.Second)
+
+	details, err := json.Marshal(cfg)
+	require.NoError(t, err)
+
+	c, err := NewGRPCHealthChecker(string(details))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	ok, msg := c.Check(context.Background())
+
+	var resp GRPCHealthResponse
+	require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+
+	return ok, resp
+}
+
+func TestNewGRPCHealthChecker(t *testing.T) {
+	c, err := NewGRPCHealthChecker("10.0.0.1:50051/payments.v1.Payments")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:50051", c.config.Address)
+	assert.Equal(t, "payments.v1.Payments", c.config.Service)
+	assert.Equal(t, grpcTLSNone, c.config.TLS)
+	require.NoError(t, c.Close())
+
+	_, err = NewGRPCHealthChecker("")
+	require.ErrorIs(t, err, errDetailsRequiredGRPCHealth)
+
+	_, err = NewGRPCHealthChecker(`{"address": "10.0.0.1:50051", "tls": "spiffe"}`)
+	require.ErrorIs(t, err, errUnsupportedGRPCTLSMode)
+
+	_, err = NewGRPCHealthChecker(`{"address": "10.0.0.1:50051", "tls": "mtls"}`)
+	require.ErrorIs(t, err, errClientCertRequired)
+}
+
+func TestGRPCHealthCheckerCheck(t *testing.T) {
+	address := startHealthServer(t)
+
+	tests := []struct {
+		name       string
+		address    string
+		service    string
+		wantOK     bool
+		wantStatus string
+	}{
+		{"server", address, "", true, "SERVING"},
+		{"serving service", address, "payments.v1.Payments", true, "SERVING"},
+		{"not serving", address, "search.v1.Search", false, "NOT_SERVING"},
+		{"unknown service", address, "unknown.v1.Unknown", false, "NotFound"},
+		{"unreachable", closedTCPAddress(t), "", false, "Unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, resp := checkGRPCHealth(t, GRPCHealthCheckerConfig{Address: tt.address, Service: tt.service})
+
+			assert.Equal(t, tt.wantOK, ok, resp.Error)
+			assert.Equal(t, tt.wantStatus, resp.Status)
+			assert.Equal(t, tt.wantOK, resp.Error == "")
+		})
+	}
+}
+
+func TestGRPCHealthCheckerMTLS(t *testing.T) {
+	caFile, serverCert, serverKey, clientCert, clientKey := writeTestPKI(t)
+
+	cert, err := tls.LoadX509KeyPair(serverCert, serverKey)
+	require.NoError(t, err)
+
+	caPEM, err := os.ReadFile(caFile)
+	require.NoError(t, err)
+
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM(caPEM))
+
+	address := startHealthServer(t, grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	})))
+
+	ok, resp := checkGRPCHealth(t, GRPCHealthCheckerConfig{
+		Address:  address,
+		TLS:      grpcMTLS,
+		CAFile:   caFile,
+		CertFile: clientCert,
+		KeyFile:  clientKey,
+	})
+	assert.True(t, ok, resp.Error)
+
+	// Without a client certificate the server rejects the handshake.
+	ok, resp = checkGRPCHealth(t, GRPCHealthCheckerConfig{Address: address, TLS: grpcTLS, CAFile: caFile})
+	assert.False(t, ok)
+	assert.Equal(t, "Unavailable", resp.Status)
+
+	// A plaintext client cannot talk to a TLS server either.
+	ok, _ = checkGRPCHealth(t, GRPCHealthCheckerConfig{Address: address})
+	assert.False(t, ok)
+}
+
+func closedTCPAddress(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	address := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	return address
+}
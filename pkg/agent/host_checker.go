@@ -0,0 +1,366 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/host_checker.go
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	cpuSampleInterval = 250 * time.Millisecond
+	percent           = 100
+	bytesPerKiB       = 1024
+	// Number of /proc/stat cpu columns counted towards total time (user..steal).
+	cpuStatColumns = 8
+	cpuIdleColumn  = 3
+	cpuIOWaitCol   = 4
+	loadAvgFields  = 3
+)
+
+// HostCheckerConfig lists the mount points to report and the thresholds
+// above which the host is reported unavailable. A zero threshold is disabled.
+type HostCheckerConfig struct {
+	MountPoints      []string `json:"mount_points,omitempty"`
+	MaxCPUPercent    float64  `json:"max_cpu_percent,omitempty"`
+	MaxMemoryPercent float64  `json:"max_memory_percent,omitempty"`
+	MaxDiskPercent   float64  `json:"max_disk_percent,omitempty"`
+	MaxLoad1         float64  `json:"max_load1,omitempty"`
+}
+
+// HostChecker reports CPU, memory, load and disk utilization of the agent host.
+type HostChecker struct {
+	config   HostCheckerConfig
+	procRoot string
+	mu       sync.Mutex
+	lastCPU  *cpuSample
+}
+
+type cpuSample struct {
+	total uint64
+	idle  uint64
+}
+
+// HostCPU holds the CPU utilization since the previous check.
+type HostCPU struct {
+	UsagePercent float64 `json:"usage_percent"`
+	Cores        int     `json:"cores"`
+}
+
+// HostMemory holds memory utilization from /proc/meminfo.
+type HostMemory struct {
+	TotalBytes     uint64  `json:"total_bytes"`
+	AvailableBytes uint64  `json:"available_bytes"`
+	UsedPercent    float64 `json:"used_percent"`
+}
+
+// HostLoad holds the load averages from /proc/loadavg.
+type HostLoad struct {
+	Load1  float64 `json:"load1"`
+	Load5  float64 `json:"load5"`
+	Load15 float64 `json:"load15"`
+}
+
+// HostDisk holds filesystem utilization for a mount point.
+type HostDisk struct {
+	MountPoint  string  `json:"mount_point"`
+	TotalBytes  uint64  `json:"total_bytes"`
+	FreeBytes   uint64  `json:"free_bytes"`
+	UsedPercent float64 `json:"used_percent"`
+}
+
+// HostResponse defines the structure of the host check result.
+type HostResponse struct {
+	CPU        HostCPU    `json:"cpu"`
+	Memory     HostMemory `json:"memory"`
+	Load       HostLoad   `json:"load"`
+	Disks      []HostDisk `json:"disks"`
+	Violations []string   `json:"violations,omitempty"`
+	Available  bool       `json:"available"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// NewHostChecker creates a HostChecker from the check details, which are
+// empty (report "/" without thresholds) or a JSON encoded HostCheckerConfig.
+func NewHostChecker(details string) (*HostChecker, error) {
+	var cfg HostCheckerConfig
+
+	if strings.TrimSpace(details) != "" {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse host check details: %w", err)
+		}
+	}
+
+	if len(cfg.MountPoints) == 0 {
+		cfg.MountPoints = []string{"/"}
+	}
+
+	return &HostChecker{config: cfg, procRoot: defaultProcRoot}, nil
+}
+
+// Check collects the host resource usage and compares it to the thresholds.
+func (c *HostChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	resp := &HostResponse{Disks: []HostDisk{}}
+
+	if err := c.collect(ctx, resp); err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Violations = c.violations(resp)
+		resp.Available = len(resp.Violations) == 0
+	}
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal host response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+func (c *HostChecker) collect(ctx context.Context, resp *HostResponse) error {
+	var err error
+
+	if resp.CPU, err = c.cpuUsage(ctx); err != nil {
+		return err
+	}
+
+	if resp.Memory, err = c.memory(); err != nil {
+		return err
+	}
+
+	if resp.Load, err = c.loadAverage(); err != nil {
+		return err
+	}
+
+	for _, mount := range c.config.MountPoints {
+		disk, err := diskUsage(mount)
+		if err != nil {
+			return err
+		}
+
+		resp.Disks = append(resp.Disks, disk)
+	}
+
+	return nil
+}
+
+func (c *HostChecker) violations(resp *HostResponse) []string {
+	var violations []string
+
+	if c.config.MaxCPUPercent > 0 && resp.CPU.UsagePercent > c.config.MaxCPUPercent {
+		violations = append(violations,
+			fmt.Sprintf("cpu usage %.1f%% exceeds %.1f%%", resp.CPU.UsagePercent, c.config.MaxCPUPercent))
+	}
+
+	if c.config.MaxMemoryPercent > 0 && resp.Memory.UsedPercent > c.config.MaxMemoryPercent {
+		violations = append(violations,
+			fmt.Sprintf("memory usage %.1f%% exceeds %.1f%%", resp.Memory.UsedPercent, c.config.MaxMemoryPercent))
+	}
+
+	if c.config.MaxLoad1 > 0 && resp.Load.Load1 > c.config.MaxLoad1 {
+		violations = append(violations,
+			fmt.Sprintf("load1 %.2f exceeds %.2f", resp.Load.Load1, c.config.MaxLoad1))
+	}
+
+	for _, disk := range resp.Disks {
+		if c.config.MaxDiskPercent > 0 && disk.UsedPercent > c.config.MaxDiskPercent {
+			violations = append(violations,
+				fmt.Sprintf("disk usage on %s %.1f%% exceeds %.1f%%", disk.MountPoint, disk.UsedPercent, c.config.MaxDiskPercent))
+		}
+	}
+
+	return violations
+}
+
+// cpuUsage returns the CPU utilization since the previous check. The first
+// check takes two samples a short interval apart.
+func (c *HostChecker) cpuUsage(ctx context.Context) (HostCPU, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current, cores, err := c.readCPUSample()
+	if err != nil {
+		return HostCPU{}, err
+	}
+
+	previous := c.lastCPU
+	if previous == nil {
+		previous = current
+
+		select {
+		case <-ctx.Done():
+			return HostCPU{}, ctx.Err()
+		case <-time.After(cpuSampleInterval):
+		}
+
+		if current, _, err = c.readCPUSample(); err != nil {
+			return HostCPU{}, err
+		}
+	}
+
+	c.lastCPU = current
+
+	usage := HostCPU{Cores: cores}
+
+	if totalDelta := current.total - previous.total; totalDelta > 0 {
+		idleDelta := current.idle - previous.idle
+		usage.UsagePercent = float64(totalDelta-idleDelta) / float64(totalDelta) * percent
+	}
+
+	return usage, nil
+}
+
+func (c *HostChecker) readCPUSample() (sample *cpuSample, cores int, err error) {
+	f, err := os.Open(filepath.Join(c.procRoot, "stat"))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read cpu stats: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+
+		if fields[0] != "cpu" {
+			cores++
+
+			continue
+		}
+
+		if len(fields) <= cpuStatColumns {
+			return nil, 0, errInvalidProcData
+		}
+
+		sample = &cpuSample{}
+
+		for i, field := range fields[1 : cpuStatColumns+1] {
+			v, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return nil, 0, fmt.Errorf("%w: %w", errInvalidProcData, err)
+			}
+
+			sample.total += v
+
+			if i == cpuIdleColumn || i == cpuIOWaitCol {
+				sample.idle += v
+			}
+		}
+	}
+
+	if sample == nil {
+		return nil, 0, errInvalidProcData
+	}
+
+	return sample, cores, nil
+}
+
+func (c *HostChecker) memory() (HostMemory, error) {
+	data, err := os.ReadFile(filepath.Join(c.procRoot, "meminfo"))
+	if err != nil {
+		return HostMemory{}, fmt.Errorf("failed to read meminfo: %w", err)
+	}
+
+	var mem HostMemory
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch fields[0] {
+		case "MemTotal:":
+			mem.TotalBytes = value * bytesPerKiB
+		case "MemAvailable:":
+			mem.AvailableBytes = value * bytesPerKiB
+		}
+	}
+
+	if mem.TotalBytes == 0 {
+		return HostMemory{}, errInvalidProcData
+	}
+
+	mem.UsedPercent = float64(mem.TotalBytes-mem.AvailableBytes) / float64(mem.TotalBytes) * percent
+
+	return mem, nil
+}
+
+func (c *HostChecker) loadAverage() (HostLoad, error) {
+	data, err := os.ReadFile(filepath.Join(c.procRoot, "loadavg"))
+	if err != nil {
+		return HostLoad{}, fmt.Errorf("failed to read loadavg: %w", err)
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) < loadAvgFields {
+		return HostLoad{}, errInvalidProcData
+	}
+
+	var loads [loadAvgFields]float64
+
+	for i := range loads {
+		if loads[i], err = strconv.ParseFloat(fields[i], 64); err != nil {
+			return HostLoad{}, fmt.Errorf("%w: %w", errInvalidProcData, err)
+		}
+	}
+
+	return HostLoad{Load1: loads[0], Load5: loads[1], Load15: loads[2]}, nil
+}
+
+// diskUsage reports usage the way df does: reserved blocks count as used.
+func diskUsage(mountPoint string) (HostDisk, error) {
+	var st syscall.Statfs_t
+
+	if err := syscall.Statfs(mountPoint, &st); err != nil {
+		return HostDisk{}, fmt.Errorf("failed to statfs %s: %w", mountPoint, err)
+	}
+
+	blockSize := uint64(st.Bsize) //nolint:gosec // block size is never negative
+	disk := HostDisk{
+		MountPoint: mountPoint,
+		TotalBytes: st.Blocks * blockSize,
+		FreeBytes:  st.Bavail * blockSize,
+	}
+
+	if usable := st.Blocks - st.Bfree + st.Bavail; usable > 0 {
+		disk.UsedPercent = float64(st.Blocks-st.Bfree) / float64(usable) * percent
+	}
+
+	return disk, nil
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFakeHostProc creates a procfs tree with stat, meminfo and loadavg.
+func writeFakeHostProc(t *testing.T, stat string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	files := map[string]string{
+		"stat":    stat,
+		"meminfo": "MemTotal:        1000000 kB\nMemFree:          100000 kB\nMemAvailable:     250000 kB\n",
+		"loadavg": "1.50 0.75 0.25 2/300 12345\n",
+	}
+
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0600))
+	}
+
+	return root
+}
+
+func TestNewHostChecker(t *testing.T) {
+	c, err := NewHostChecker("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/"}, c.config.MountPoints)
+
+	c, err = NewHostChecker(`{"mount_points": ["/", "/tmp"], "max_cpu_percent": 90}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/", "/tmp"}, c.config.MountPoints)
+	assert.InDelta(t, 90.0, c.config.MaxCPUPercent, 0.001)
+
+	_, err = NewHostChecker(`{"mount_points": `)
+	require.Error(t, err)
+}
+
+func TestHostCheckerCheck(t *testing.T) {
+	tests := []struct {
+		name           string
+		details        string
+		wantOK         bool
+		wantViolations int
+	}{
+		{name: "no thresholds", details: "", wantOK: true},
+		{name: "within thresholds", details: `{"max_cpu_percent": 80, "max_memory_percent": 80, "max_load1": 2}`, wantOK: true},
+		{name: "cpu exceeded", details: `{"max_cpu_percent": 10}`, wantOK: false, wantViolations: 1},
+		{name: "memory and load exceeded", details: `{"max_memory_percent": 70, "max_load1": 1}`, wantOK: false, wantViolations: 2},
+		{name: "disk exceeded", details: `{"max_disk_percent": 0.0001}`, wantOK: false, wantViolations: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeFakeHostProc(t, "cpu  100 0 100 800 0 0 0 0 0 0\ncpu0 50 0 50 400 0 0 0 0 0 0\ncpu1 50 0 50 400 0 0 0 0 0 0\n")
+
+			c, err := NewHostChecker(tt.details)
+			require.NoError(t, err)
+
+			c.procRoot = root
+			c.lastCPU = &cpuSample{total: 0, idle: 0} // 20% busy since boot
+
+			ok, msg := c.Check(context.Background())
+			assert.Equal(t, tt.wantOK, ok, msg)
+
+			var resp HostResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+			assert.Empty(t, resp.Error)
+			assert.Len(t, resp.Violations, tt.wantViolations)
+			assert.InDelta(t, 20.0, resp.CPU.UsagePercent, 0.001)
+			assert.Equal(t, 2, resp.CPU.Cores)
+			assert.Equal(t, uint64(1000000*1024), resp.Memory.TotalBytes)
+			assert.InDelta(t, 75.0, resp.Memory.UsedPercent, 0.001)
+			assert.InDelta(t, 1.5, resp.Load.Load1, 0.001)
+			assert.InDelta(t, 0.25, resp.Load.Load15, 0.001)
+			require.Len(t, resp.Disks, 1)
+			assert.Equal(t, "/", resp.Disks[0].MountPoint)
+			assert.Positive(t, resp.Disks[0].TotalBytes)
+		})
+	}
+}
+
+func TestHostCheckerCPUDelta(t *testing.T) {
+	root := writeFakeHostProc(t, "cpu  100 0 100 800 0 0 0 0 0 0\n")
+
+	c, err := NewHostChecker("")
+	require.NoError(t, err)
+
+	c.procRoot = root
+
+	// The first check samples twice; with an unchanged stat file usage is zero.
+	_, msg := c.Check(context.Background())
+
+	var resp HostResponse
+	require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+	assert.Zero(t, resp.CPU.UsagePercent)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "stat"), []byte("cpu  190 0 100 810 0 0 0 0 0 0\n"), 0600))
+
+	_, msg = c.Check(context.Background())
+	require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+	assert.InDelta(t, 90.0, resp.CPU.UsagePercent, 0.001)
+}
+
+func TestHostCheckerMissingProc(t *testing.T) {
+	c, err := NewHostChecker("")
+	require.NoError(t, err)
+
+	c.procRoot = t.TempDir()
+
+	ok, msg := c.Check(context.Background())
+	assert.False(t, ok)
+
+	var resp HostResponse
+	require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+	assert.Contains(t, resp.Error, "failed to read cpu stats")
+}
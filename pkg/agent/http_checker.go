@@ -0,0 +1,324 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/http_checker.go
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHTTPTimeout      = 10 * time.Second
+	defaultHTTPMaxRedirects = 10
+	maxHTTPBodyBytes        = 1 << 20 // Only the first 1MiB of a body is matched
+)
+
+// HTTPCheckerConfig describes the request an HTTPChecker performs and
+// the assertions made against the response.
+type HTTPCheckerConfig struct {
+	URL                string            `json:"url"`
+	Method             string            `json:"method,omitempty"`
+	Headers            map[string]string `json:"headers,omitempty"`
+	Body               string            `json:"body,omitempty"`
+	ExpectedStatus     []int             `json:"expected_status,omitempty"`
+	BodyContains       string            `json:"body_contains,omitempty"`
+	BodyRegex          string            `json:"body_regex,omitempty"`
+	FollowRedirects    *bool             `json:"follow_redirects,omitempty"`
+	MaxRedirects       *int              `json:"max_redirects,omitempty"` // default 10, 0 fails on any redirect
+	InsecureSkipVerify bool              `json:"insecure_skip_verify,omitempty"`
+	Timeout            Duration          `json:"timeout,omitempty"`
+}
+
+// HTTPChecker performs HTTP/HTTPS requests against an endpoint.
+type HTTPChecker struct {
+	config    HTTPCheckerConfig
+	bodyRegex *regexp.Regexp
+	client    *http.Client
+}
+
+// HTTPTiming holds the timing breakdown of a request in nanoseconds.
+type HTTPTiming struct {
+	DNS     int64 `json:"dns"`
+	Connect int64 `json:"connect"`
+	TLS     int64 `json:"tls"`
+	TTFB    int64 `json:"ttfb"`
+	Total   int64 `json:"total"`
+}
+
+// HTTPResponse defines the structure of the HTTP check result.
+type HTTPResponse struct {
+	URL          string     `json:"url"`
+	Method       string     `json:"method"`
+	StatusCode   int        `json:"status_code,omitempty"`
+	FinalURL     string     `json:"final_url,omitempty"`
+	BodySize     int        `json:"body_size"`
+	ResponseTime int64      `json:"response_time"` // in nanoseconds
+	Timing       HTTPTiming `json:"timing"`
+	Available    bool       `json:"available"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// NewHTTPChecker creates an HTTPChecker from the check details, which are
+// either a plain URL or a JSON encoded HTTPCheckerConfig.
+func NewHTTPChecker(details string) (*HTTPChecker, error) {
+	if details == "" {
+		return nil, errDetailsRequiredHTTP
+	}
+
+	var cfg HTTPCheckerConfig
+
+	if strings.HasPrefix(strings.TrimSpace(details), "{") {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse HTTP check details: %w", err)
+		}
+	} else {
+		cfg.URL = strings.TrimSpace(details)
+	}
+
+	if err := cfg.applyDefaults(); err != nil {
+		return nil, err
+	}
+
+	c := &HTTPChecker{config: cfg}
+
+	if cfg.BodyRegex != "" {
+		re, err := regexp.Compile(cfg.BodyRegex)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidBodyRegex, err)
+		}
+
+		c.bodyRegex = re
+	}
+
+	c.client = &http.Client{
+		Transport: &http.Transport{
+			Proxy:             http.ProxyFromEnvironment,
+			DisableKeepAlives: true, // every check measures a full connection setup
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // opt-in for self-signed endpoints
+			},
+		},
+		CheckRedirect: c.checkRedirect,
+	}
+
+	return c, nil
+}
+
+func (cfg *HTTPCheckerConfig) applyDefaults() error {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("%w: %q", errInvalidURL, cfg.URL)
+	}
+
+	if cfg.Method == "" {
+		cfg.Method = http.MethodGet
+	}
+
+	cfg.Method = strings.ToUpper(cfg.Method)
+
+	if len(cfg.ExpectedStatus) == 0 {
+		cfg.ExpectedStatus = []int{http.StatusOK}
+	}
+
+	if cfg.MaxRedirects == nil {
+		maxRedirects := defaultHTTPMaxRedirects
+		cfg.MaxRedirects = &maxRedirects
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = Duration(defaultHTTPTimeout)
+	}
+
+	return nil
+}
+
+func (c *HTTPChecker) checkRedirect(_ *http.Request, via []*http.Request) error {
+	if c.config.FollowRedirects != nil && !*c.config.FollowRedirects {
+		return http.ErrUseLastResponse
+	}
+
+	if len(via) >= *c.config.MaxRedirects {
+		return fmt.Errorf("%w: stopped after %d redirects", errTooManyRedirects, len(via))
+	}
+
+	return nil
+}
+
+// Check performs the HTTP request and validates the response.
+func (c *HTTPChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout))
+	defer cancel()
+
+	resp := &HTTPResponse{
+		URL:    c.config.URL,
+		Method: c.config.Method,
+	}
+
+	if err := c.do(ctx, resp); err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Available = true
+	}
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal HTTP response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+func (c *HTTPChecker) do(ctx context.Context, result *HTTPResponse) error {
+	var body io.Reader
+	if c.config.Body != "" {
+		body = strings.NewReader(c.config.Body)
+	}
+
+	tracer := &httpTracer{}
+
+	ctx = httptrace.WithClientTrace(ctx, tracer.trace())
+
+	req, err := http.NewRequestWithContext(ctx, c.config.Method, c.config.URL, body)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	for key, value := range c.config.Headers {
+		if strings.EqualFold(key, "Host") {
+			req.Host = value
+
+			continue
+		}
+
+		req.Header.Set(key, value)
+	}
+
+	start := time.Now()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		result.Timing = tracer.timing(start, time.Now())
+		result.ResponseTime = result.Timing.Total
+
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("Error closing HTTP response body: %v", err)
+		}
+	}()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPBodyBytes))
+
+	result.Timing = tracer.timing(start, time.Now())
+	result.ResponseTime = result.Timing.Total
+	result.StatusCode = resp.StatusCode
+	result.FinalURL = resp.Request.URL.String()
+	result.BodySize = len(data)
+
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return c.validate(resp.StatusCode, data)
+}
+
+func (c *HTTPChecker) validate(statusCode int, body []byte) error {
+	if !slices.Contains(c.config.ExpectedStatus, statusCode) {
+		return fmt.Errorf("%w: got %d, expected one of %v", errUnexpectedStatus, statusCode, c.config.ExpectedStatus)
+	}
+
+	if c.config.BodyContains != "" && !strings.Contains(string(body), c.config.BodyContains) {
+		return fmt.Errorf("%w: missing %q", errBodyMismatch, c.config.BodyContains)
+	}
+
+	if c.bodyRegex != nil && !c.bodyRegex.Match(body) {
+		return fmt.Errorf("%w: no match for %q", errBodyMismatch, c.config.BodyRegex)
+	}
+
+	return nil
+}
+
+// httpTracer records the timestamps of the connection phases of a request.
+// When redirects are followed the phases of the last hop are reported.
+type httpTracer struct {
+	mu                        sync.Mutex
+	dnsStart, dnsDone         time.Time
+	connectStart, connectDone time.Time
+	tlsStart, tlsDone         time.Time
+	firstByte                 time.Time
+}
+
+func (t *httpTracer) mark(field *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	*field = time.Now()
+}
+
+func (t *httpTracer) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.mark(&t.dnsDone) },
+		ConnectStart:         func(_, _ string) { t.mark(&t.connectStart) },
+		ConnectDone:          func(_, _ string, _ error) { t.mark(&t.connectDone) },
+		TLSHandshakeStart:    func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.mark(&t.tlsDone) },
+		GotFirstResponseByte: func() { t.mark(&t.firstByte) },
+	}
+}
+
+func (t *httpTracer) timing(start, end time.Time) HTTPTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timing := HTTPTiming{
+		DNS:     phaseDuration(t.dnsStart, t.dnsDone),
+		Connect: phaseDuration(t.connectStart, t.connectDone),
+		TLS:     phaseDuration(t.tlsStart, t.tlsDone),
+		Total:   end.Sub(start).Nanoseconds(),
+	}
+
+	if !t.firstByte.IsZero() {
+		timing.TTFB = t.firstByte.Sub(start).Nanoseconds()
+	}
+
+	return timing
+}
+
+func phaseDuration(start, end time.Time) int64 {
+	if start.IsZero() || end.IsZero() {
+		return 0
+	}
+
+	return end.Sub(start).Nanoseconds()
+}
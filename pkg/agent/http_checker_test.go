@@ -0,0 +1,205 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPChecker(t *testing.T) {
+	tests := []struct {
+		name       string
+		details    string
+		wantURL    string
+		wantMethod string
+		wantError  bool
+	}{
+		{
+			name:       "plain URL",
+			details:    "https://example.com/health",
+			wantURL:    "https://example.com/health",
+			wantMethod: http.MethodGet,
+		},
+		{
+			name:       "JSON config",
+			details:    `{"url": "http://localhost:8080", "method": "post"}`,
+			wantURL:    "http://localhost:8080",
+			wantMethod: http.MethodPost,
+		},
+		{
+			name:      "empty details",
+			details:   "",
+			wantError: true,
+		},
+		{
+			name:      "unsupported scheme",
+			details:   "ftp://example.com",
+			wantError: true,
+		},
+		{
+			name:      "invalid JSON",
+			details:   `{"url": `,
+			wantError: true,
+		},
+		{
+			name:      "invalid body regex",
+			details:   `{"url": "http://localhost", "body_regex": "("}`,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewHTTPChecker(tt.details)
+			if tt.wantError {
+				require.Error(t, err)
+				assert.Nil(t, c)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantURL, c.config.URL)
+			assert.Equal(t, tt.wantMethod, c.config.Method)
+		})
+	}
+}
+
+func TestHTTPCheckerCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"status": "ok", "version": "1.2.3"}`)
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		_, _ = io.Copy(w, r.Body)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/health", http.StatusFound)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		config     string
+		wantOK     bool
+		wantStatus int
+	}{
+		{
+			name:       "default expectations",
+			config:     `{"url": "%s/health"}`,
+			wantOK:     true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "body substring and regex",
+			config:     `{"url": "%s/health", "body_contains": "\"ok\"", "body_regex": "version\": \"1\\.\\d+"}`,
+			wantOK:     true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "body mismatch",
+			config:     `{"url": "%s/health", "body_contains": "degraded"}`,
+			wantOK:     false,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "method headers and body",
+			config:     `{"url": "%s/echo", "method": "POST", "headers": {"X-Token": "secret"}, "body": "ping", "body_contains": "ping"}`,
+			wantOK:     true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unexpected status",
+			config:     `{"url": "%s/echo"}`,
+			wantOK:     false,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "redirect followed",
+			config:     `{"url": "%s/moved"}`,
+			wantOK:     true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "redirect not followed",
+			config:     `{"url": "%s/moved", "follow_redirects": false, "expected_status": [302]}`,
+			wantOK:     true,
+			wantStatus: http.StatusFound,
+		},
+		{
+			name:   "redirects not allowed",
+			config: `{"url": "%s/moved", "max_redirects": 0}`,
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewHTTPChecker(fmt.Sprintf(tt.config, server.URL))
+			require.NoError(t, err)
+
+			ok, msg := c.Check(context.Background())
+			assert.Equal(t, tt.wantOK, ok, msg)
+
+			var resp HTTPResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantOK, resp.Available)
+			assert.Positive(t, resp.Timing.Total)
+			assert.Positive(t, resp.Timing.TTFB)
+			assert.Equal(t, resp.Timing.Total, resp.ResponseTime)
+
+			if !tt.wantOK {
+				assert.NotEmpty(t, resp.Error)
+			}
+		})
+	}
+}
+
+func TestHTTPCheckerConnectionRefused(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	addr := server.URL
+	server.Close()
+
+	c, err := NewHTTPChecker(addr)
+	require.NoError(t, err)
+
+	ok, msg := c.Check(context.Background())
+	assert.False(t, ok)
+
+	var resp HTTPResponse
+	require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+	assert.NotEmpty(t, resp.Error)
+	assert.Zero(t, resp.StatusCode)
+}
@@ -0,0 +1,362 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/logwatch_checker.go
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLogwatchWindow   = 5 * time.Minute
+	defaultLogwatchMaxLines = 10
+	logReadChunkBytes       = 32 * 1024
+	maxLogReadBytes         = 64 << 20 // per check; the rest is read by the next check
+	maxLogLineBytes         = 64 * 1024
+	maxStoredLogLineBytes   = 1024
+	// maxLogMatches bounds the matches kept for the window; counts saturate
+	// there, so thresholds should stay well below it.
+	maxLogMatches = 10000
+)
+
+// LogwatchCheckerConfig describes the file a LogwatchChecker follows and the
+// number of matching lines tolerated within the window.
+type LogwatchCheckerConfig struct {
+	Path      string   `json:"path"`
+	Patterns  []string `json:"patterns"`
+	Window    Duration `json:"window,omitempty"`
+	Threshold int      `json:"threshold,omitempty"` // the check fails above this many matches
+	MaxLines  int      `json:"max_lines,omitempty"`
+	FromStart bool     `json:"from_start,omitempty"` // also scan the existing content on the first check
+}
+
+// LogwatchChecker tails a log file and counts lines matching any of the
+// configured patterns. The file is read on every check, so matches are
+// timestamped when they are read, not when they were written. Rotation by
+// rename is followed by draining the old file before opening the new one,
+// and a file truncated in place is read again from the start.
+type LogwatchChecker struct {
+	config   LogwatchCheckerConfig
+	patterns []*regexp.Regexp
+	now      func() time.Time
+
+	mu        sync.Mutex
+	file      *os.File
+	offset    int64
+	partial   []byte
+	matches   []logMatch // oldest first
+	rotations int
+}
+
+type logMatch struct {
+	at      time.Time
+	pattern int
+	line    string // only kept for the last MaxLines matches
+}
+
+// LogwatchResponse defines the structure of the logwatch check result.
+// Matches and PatternMatches count the lines matched within the window.
+type LogwatchResponse struct {
+	Path           string         `json:"path"`
+	Matches        int            `json:"matches"`
+	PatternMatches map[string]int `json:"pattern_matches,omitempty"`
+	Threshold      int            `json:"threshold"`
+	Window         int64          `json:"window"` // in nanoseconds
+	LastLines      []string       `json:"last_lines,omitempty"`
+	BytesRead      int64          `json:"bytes_read"`
+	Rotations      int            `json:"rotations"`
+	ResponseTime   int64          `json:"response_time"`
+	Available      bool           `json:"available"`
+	Error          string         `json:"error,omitempty"`
+}
+
+// NewLogwatchChecker creates a LogwatchChecker from the check details, which
+// are either "path:regex" or a JSON encoded LogwatchCheckerConfig.
+func NewLogwatchChecker(details string) (*LogwatchChecker, error) {
+	details = strings.TrimSpace(details)
+
+	var cfg LogwatchCheckerConfig
+
+	if strings.HasPrefix(details, "{") {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse logwatch check details: %w", err)
+		}
+	} else if path, pattern, found := strings.Cut(details, ":"); found {
+		cfg.Path, cfg.Patterns = path, []string{pattern}
+	}
+
+	if cfg.Path == "" || len(cfg.Patterns) == 0 {
+		return nil, errDetailsRequiredLogwatch
+	}
+
+	if cfg.Window == 0 {
+		cfg.Window = Duration(defaultLogwatchWindow)
+	}
+
+	if cfg.MaxLines == 0 {
+		cfg.MaxLines = defaultLogwatchMaxLines
+	}
+
+	c := &LogwatchChecker{config: cfg, now: time.Now}
+
+	for _, pattern := range cfg.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidLogPattern, err)
+		}
+
+		c.patterns = append(c.patterns, re)
+	}
+
+	return c, nil
+}
+
+// Check reads the lines appended since the previous check and compares the
+// number of matches within the window to the threshold.
+func (c *LogwatchChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := &LogwatchResponse{
+		Path:      c.config.Path,
+		Threshold: c.config.Threshold,
+		Window:    time.Duration(c.config.Window).Nanoseconds(),
+	}
+
+	start := time.Now()
+
+	err := c.poll(ctx, resp)
+
+	c.summarize(resp)
+
+	resp.ResponseTime = time.Since(start).Nanoseconds()
+
+	switch {
+	case err != nil:
+		resp.Error = err.Error()
+	case resp.Matches > c.config.Threshold:
+		resp.Error = fmt.Sprintf("%v: %d matches in %v", errLogThresholdExceeded, resp.Matches, time.Duration(c.config.Window))
+	default:
+		resp.Available = true
+	}
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal logwatch response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+// Close releases the followed file.
+func (c *LogwatchChecker) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return nil
+	}
+
+	err := c.file.Close()
+	c.file = nil
+
+	return err
+}
+
+// poll detects rotation and truncation and reads the new lines.
+func (c *LogwatchChecker) poll(ctx context.Context, resp *LogwatchResponse) error {
+	info, statErr := os.Stat(c.config.Path)
+
+	if c.file == nil {
+		if statErr != nil {
+			return fmt.Errorf("failed to open log file: %w", statErr)
+		}
+
+		if err := c.open(!c.config.FromStart); err != nil {
+			return err
+		}
+
+		return c.read(ctx, resp)
+	}
+
+	current, err := c.file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	switch {
+	case statErr == nil && !os.SameFile(info, current):
+		// Rotated: finish the old file, then follow the new one from its start.
+		if err := c.read(ctx, resp); err != nil {
+			return err
+		}
+
+		c.flushPartial()
+		_ = c.file.Close()
+		c.file = nil
+		c.rotations++
+
+		if err := c.open(false); err != nil {
+			return err
+		}
+	case current.Size() < c.offset:
+		// Truncated in place (copytruncate).
+		c.offset, c.partial = 0, nil
+	}
+
+	// While the new file is not created yet, keep reading the old one.
+	return c.read(ctx, resp)
+}
+
+func (c *LogwatchChecker) open(seekEnd bool) error {
+	f, err := os.Open(c.config.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	c.file, c.offset, c.partial = f, 0, nil
+
+	if seekEnd {
+		if c.offset, err = f.Seek(0, io.SeekEnd); err != nil {
+			_ = f.Close()
+			c.file = nil
+
+			return fmt.Errorf("failed to seek log file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// read consumes complete lines from the current offset. An unterminated last
+// line is kept until the rest of it is written.
+func (c *LogwatchChecker) read(ctx context.Context, resp *LogwatchResponse) error {
+	buf := make([]byte, logReadChunkBytes)
+
+	for read := 0; read < maxLogReadBytes && ctx.Err() == nil; {
+		n, err := c.file.ReadAt(buf, c.offset)
+
+		c.offset += int64(n)
+		resp.BytesRead += int64(n)
+		read += n
+
+		c.consume(buf[:n])
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read log file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *LogwatchChecker) consume(data []byte) {
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			c.partial = append(c.partial, data...)
+
+			if len(c.partial) > maxLogLineBytes {
+				c.flushPartial()
+			}
+
+			return
+		}
+
+		c.partial = append(c.partial, data[:i]...)
+		c.flushPartial()
+
+		data = data[i+1:]
+	}
+}
+
+func (c *LogwatchChecker) flushPartial() {
+	if len(c.partial) > 0 {
+		c.matchLine(bytes.TrimRight(c.partial, "\r"))
+	}
+
+	c.partial = c.partial[:0]
+}
+
+func (c *LogwatchChecker) matchLine(line []byte) {
+	for i, re := range c.patterns {
+		if !re.Match(line) {
+			continue
+		}
+
+		if len(line) > maxStoredLogLineBytes {
+			line = line[:maxStoredLogLineBytes]
+		}
+
+		c.matches = append(c.matches, logMatch{at: c.now(), pattern: i, line: string(line)})
+
+		if n := len(c.matches) - c.config.MaxLines - 1; n >= 0 {
+			c.matches[n].line = ""
+		}
+
+		if len(c.matches) > maxLogMatches {
+			c.matches = c.matches[len(c.matches)-maxLogMatches:]
+		}
+
+		return
+	}
+}
+
+// summarize drops matches that left the window and fills in the counts and
+// the last matching lines.
+func (c *LogwatchChecker) summarize(resp *LogwatchResponse) {
+	cutoff := c.now().Add(-time.Duration(c.config.Window))
+
+	expired := 0
+	for expired < len(c.matches) && !c.matches[expired].at.After(cutoff) {
+		expired++
+	}
+
+	c.matches = c.matches[expired:]
+
+	resp.Matches = len(c.matches)
+	resp.Rotations = c.rotations
+
+	for i, match := range c.matches {
+		if resp.PatternMatches == nil {
+			resp.PatternMatches = make(map[string]int)
+		}
+
+		resp.PatternMatches[c.config.Patterns[match.pattern]]++
+
+		if i >= len(c.matches)-c.config.MaxLines {
+			resp.LastLines = append(resp.LastLines, match.line)
+		}
+	}
+}
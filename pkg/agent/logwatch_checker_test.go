@@ -0,0 +1,189 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendLog(t *testing.T, path, text string) {
+	t.Helper()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+
+	_, err = f.WriteString(text)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func checkLogwatch(t *testing.T, c *LogwatchChecker) (bool, LogwatchResponse) {
+	t.Helper()
+
+	ok, msg := c.Check(context.Background())
+
+	var resp LogwatchResponse
+	require.NoError(t, json.Unmarshal([]byte(msg), &resp), msg)
+
+	return ok, resp
+}
+
+func newTestLogwatch(t *testing.T, path string, extra string) *LogwatchChecker {
+	t.Helper()
+
+	c, err := NewLogwatchChecker(fmt.Sprintf(`{"path": %q, "patterns": ["FATAL", "OOMKilled"], "max_lines": 2%s}`, path, extra))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c
+}
+
+func TestNewLogwatchChecker(t *testing.T) {
+	c, err := NewLogwatchChecker("/var/log/syslog:OOMKilled|FATAL")
+	require.NoError(t, err)
+	assert.Equal(t, "/var/log/syslog", c.config.Path)
+	assert.Equal(t, []string{"OOMKilled|FATAL"}, c.config.Patterns)
+	assert.Equal(t, Duration(defaultLogwatchWindow), c.config.Window)
+	assert.Equal(t, defaultLogwatchMaxLines, c.config.MaxLines)
+
+	_, err = NewLogwatchChecker("/var/log/syslog")
+	require.ErrorIs(t, err, errDetailsRequiredLogwatch)
+
+	_, err = NewLogwatchChecker(`{"path": "/var/log/syslog", "patterns": ["("]}`)
+	require.ErrorIs(t, err, errInvalidLogPattern)
+}
+
+func TestLogwatchCheckerThreshold(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendLog(t, path, "FATAL before the agent started\n")
+
+	c := newTestLogwatch(t, path, `, "threshold": 2`)
+
+	// Existing content is skipped.
+	ok, resp := checkLogwatch(t, c)
+	require.True(t, ok, resp.Error)
+	assert.Zero(t, resp.Matches)
+
+	appendLog(t, path, "INFO started\nFATAL one\ncontainer OOMKilled\r\n")
+
+	ok, resp = checkLogwatch(t, c)
+	require.True(t, ok, resp.Error)
+	assert.Equal(t, 2, resp.Matches)
+	assert.Equal(t, map[string]int{"FATAL": 1, "OOMKilled": 1}, resp.PatternMatches)
+
+	appendLog(t, path, "FATAL two\nFATAL thr")
+
+	ok, resp = checkLogwatch(t, c)
+	assert.False(t, ok)
+	assert.Equal(t, 3, resp.Matches)
+	assert.Equal(t, []string{"container OOMKilled", "FATAL two"}, resp.LastLines)
+	assert.Contains(t, resp.Error, errLogThresholdExceeded.Error())
+
+	// The unterminated line is matched once it is complete.
+	appendLog(t, path, "ee\n")
+
+	_, resp = checkLogwatch(t, c)
+	assert.Equal(t, 4, resp.Matches)
+	assert.Equal(t, []string{"FATAL two", "FATAL three"}, resp.LastLines)
+}
+
+func TestLogwatchCheckerWindow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendLog(t, path, "FATAL old\n")
+
+	now := time.Now()
+
+	c := newTestLogwatch(t, path, `, "window": "1m", "from_start": true`)
+	c.now = func() time.Time { return now }
+
+	ok, resp := checkLogwatch(t, c)
+	assert.False(t, ok)
+	assert.Equal(t, 1, resp.Matches)
+
+	now = now.Add(30 * time.Second)
+
+	appendLog(t, path, "FATAL new\n")
+
+	_, resp = checkLogwatch(t, c)
+	assert.Equal(t, 2, resp.Matches)
+
+	now = now.Add(45 * time.Second)
+
+	_, resp = checkLogwatch(t, c)
+	assert.Equal(t, 1, resp.Matches)
+	assert.Equal(t, []string{"FATAL new"}, resp.LastLines)
+
+	now = now.Add(time.Minute)
+
+	ok, resp = checkLogwatch(t, c)
+	assert.True(t, ok, resp.Error)
+	assert.Zero(t, resp.Matches)
+	assert.Empty(t, resp.LastLines)
+}
+
+func TestLogwatchCheckerRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendLog(t, path, "INFO start\n")
+
+	c := newTestLogwatch(t, path, "")
+
+	ok, _ := checkLogwatch(t, c)
+	require.True(t, ok)
+
+	// Lines written to the old file before the writer reopens are not lost.
+	require.NoError(t, os.Rename(path, path+".1"))
+	appendLog(t, path+".1", "FATAL late write\n")
+	appendLog(t, path, "FATAL in new file\n")
+
+	_, resp := checkLogwatch(t, c)
+	assert.Equal(t, 1, resp.Rotations)
+	assert.Equal(t, []string{"FATAL late write", "FATAL in new file"}, resp.LastLines)
+
+	// copytruncate
+	require.NoError(t, os.Truncate(path, 0))
+	appendLog(t, path, "OOMKilled\n")
+
+	_, resp = checkLogwatch(t, c)
+	assert.Equal(t, 3, resp.Matches)
+	assert.Equal(t, []string{"FATAL in new file", "OOMKilled"}, resp.LastLines)
+
+	// Between the rename and the creation of the new file the old one is kept.
+	require.NoError(t, os.Rename(path, path+".2"))
+
+	_, resp = checkLogwatch(t, c)
+	assert.Equal(t, 1, resp.Rotations)
+	assert.Equal(t, 3, resp.Matches)
+}
+
+func TestLogwatchCheckerMissingFile(t *testing.T) {
+	c := newTestLogwatch(t, filepath.Join(t.TempDir(), "missing.log"), "")
+
+	ok, resp := checkLogwatch(t, c)
+	assert.False(t, ok)
+	assert.Contains(t, resp.Error, "failed to open log file")
+}
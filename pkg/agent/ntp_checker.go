@@ -0,0 +1,303 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/ntp_checker.go
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	defaultNTPTimeout   = 2 * time.Second
+	defaultNTPMaxOffset = 500 * time.Millisecond
+	defaultNTPPort      = "123"
+
+	// Seconds between the NTP epoch (1900) and the Unix epoch (1970).
+	ntpEpochOffset   = 2208988800
+	ntpFractionScale = 1 << 32
+	ntpShortScale    = 1 << 16
+	ntpLeapShift     = 6
+	ntpLeapNotInSync = 3
+
+	// Byte offsets in the NTP packet.
+	ntpOffsetStratum   = 1
+	ntpOffsetRootDelay = 4
+	ntpOffsetRootDisp  = 8
+	ntpOffsetRefID     = 12
+	ntpOffsetOrigin    = 24
+	ntpOffsetReceive   = 32
+	ntpOffsetTransmit  = 40
+)
+
+// NTPCheckerConfig lists the servers to query and the largest clock offset
+// the agent may have before the check fails.
+type NTPCheckerConfig struct {
+	Servers   []string `json:"servers"`
+	MaxOffset Duration `json:"max_offset,omitempty"`
+	Timeout   Duration `json:"timeout,omitempty"` // per server
+}
+
+// NTPChecker measures the agent's clock offset against NTP servers.
+type NTPChecker struct {
+	config NTPCheckerConfig
+}
+
+// NTPServerResult holds the measurement against a single server. Offset is
+// positive when the server's clock is ahead of the agent's.
+type NTPServerResult struct {
+	Address        string `json:"address"`
+	Reachable      bool   `json:"reachable"`
+	Stratum        int    `json:"stratum,omitempty"`
+	ReferenceID    string `json:"reference_id,omitempty"`
+	Offset         int64  `json:"offset"`
+	Delay          int64  `json:"delay"`
+	RootDelay      int64  `json:"root_delay"`
+	RootDispersion int64  `json:"root_dispersion"`
+	Error          string `json:"error,omitempty"`
+}
+
+// NTPResponse defines the structure of the NTP check result. The offset,
+// stratum and root delay are taken from the reachable server with the
+// lowest round-trip delay.
+type NTPResponse struct {
+	Servers      []NTPServerResult `json:"servers"`
+	Reachable    int               `json:"reachable"`
+	Offset       int64             `json:"offset"`
+	Stratum      int               `json:"stratum"`
+	RootDelay    int64             `json:"root_delay"`
+	ResponseTime int64             `json:"response_time"`
+	Available    bool              `json:"available"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// NewNTPChecker creates an NTPChecker from the check details, which are
+// either a comma separated list of servers or a JSON encoded NTPCheckerConfig.
+func NewNTPChecker(details string) (*NTPChecker, error) {
+	details = strings.TrimSpace(details)
+
+	var cfg NTPCheckerConfig
+
+	if strings.HasPrefix(details, "{") {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse ntp check details: %w", err)
+		}
+	} else if details != "" {
+		for _, server := range strings.Split(details, ",") {
+			cfg.Servers = append(cfg.Servers, strings.TrimSpace(server))
+		}
+	}
+
+	if len(cfg.Servers) == 0 {
+		return nil, errDetailsRequiredNTP
+	}
+
+	for i, server := range cfg.Servers {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			cfg.Servers[i] = net.JoinHostPort(server, defaultNTPPort)
+		}
+	}
+
+	if cfg.MaxOffset == 0 {
+		cfg.MaxOffset = Duration(defaultNTPMaxOffset)
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = Duration(defaultNTPTimeout)
+	}
+
+	return &NTPChecker{config: cfg}, nil
+}
+
+// Check queries every server and compares the offset to the threshold.
+func (c *NTPChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	resp := &NTPResponse{Servers: make([]NTPServerResult, 0, len(c.config.Servers))}
+
+	start := time.Now()
+
+	var best *NTPServerResult
+
+	for _, server := range c.config.Servers {
+		result := c.query(ctx, server)
+		resp.Servers = append(resp.Servers, result)
+
+		if result.Reachable {
+			resp.Reachable++
+
+			if best == nil || result.Delay < best.Delay {
+				best = &resp.Servers[len(resp.Servers)-1]
+			}
+		}
+	}
+
+	resp.ResponseTime = time.Since(start).Nanoseconds()
+
+	switch {
+	case best == nil:
+		resp.Error = errNoNTPServerReachable.Error()
+	case absDuration(time.Duration(best.Offset)) > time.Duration(c.config.MaxOffset):
+		resp.Error = fmt.Sprintf("%v: %v exceeds %v", errClockOffset, time.Duration(best.Offset), time.Duration(c.config.MaxOffset))
+	default:
+		resp.Available = true
+	}
+
+	if best != nil {
+		resp.Offset, resp.Stratum, resp.RootDelay = best.Offset, best.Stratum, best.RootDelay
+	}
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal ntp response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+func (c *NTPChecker) query(ctx context.Context, server string) NTPServerResult {
+	result := NTPServerResult{Address: server}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout))
+	defer cancel()
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "udp", server)
+	if err != nil {
+		result.Error = err.Error()
+
+		return result
+	}
+	defer func() { _ = conn.Close() }()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	request := make([]byte, ntpPacketSize)
+	request[0] = ntpClientHeader
+
+	t1 := time.Now()
+	putNTPTime(request[ntpOffsetTransmit:], t1)
+
+	if _, err := conn.Write(request); err != nil {
+		result.Error = err.Error()
+
+		return result
+	}
+
+	reply := make([]byte, ntpPacketSize)
+
+	n, err := conn.Read(reply)
+
+	t4 := time.Now()
+
+	if err != nil {
+		result.Error = err.Error()
+
+		return result
+	}
+
+	if err := parseNTPReply(request, reply[:n], t1, t4, &result); err != nil {
+		result.Error = err.Error()
+
+		return result
+	}
+
+	result.Reachable = true
+
+	return result
+}
+
+// parseNTPReply validates a server reply and computes offset and delay from
+// the four timestamps as described in RFC 5905.
+func parseNTPReply(request, reply []byte, t1, t4 time.Time, result *NTPServerResult) error {
+	if err := validateNTPReply(request, reply); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(reply[ntpOffsetOrigin:ntpOffsetReceive], request[ntpOffsetTransmit:ntpPacketSize]) {
+		return fmt.Errorf("%w: origin timestamp does not match the request", errUnexpectedUDPReply)
+	}
+
+	result.Stratum = int(reply[ntpOffsetStratum])
+	result.ReferenceID = ntpReferenceID(result.Stratum, reply[ntpOffsetRefID:ntpOffsetRefID+4])
+
+	if result.Stratum == 0 {
+		return fmt.Errorf("%w: kiss code %s", errNTPServerUnsynchronized, result.ReferenceID)
+	}
+
+	if reply[0]>>ntpLeapShift == ntpLeapNotInSync {
+		return errNTPServerUnsynchronized
+	}
+
+	t2 := ntpTime(reply[ntpOffsetReceive:])
+	t3 := ntpTime(reply[ntpOffsetTransmit:])
+
+	result.Offset = (t2.Sub(t1) + t3.Sub(t4)).Nanoseconds() / 2 //nolint:mnd // mean of the two one-way offsets
+	result.Delay = (t4.Sub(t1) - t3.Sub(t2)).Nanoseconds()
+	result.RootDelay = ntpShortDuration(reply[ntpOffsetRootDelay:]).Nanoseconds()
+	result.RootDispersion = ntpShortDuration(reply[ntpOffsetRootDisp:]).Nanoseconds()
+
+	return nil
+}
+
+// ntpReferenceID is an ASCII source name for stratum 0 and 1 servers and
+// the IPv4 address of the upstream server otherwise.
+func ntpReferenceID(stratum int, id []byte) string {
+	if stratum <= 1 {
+		return strings.TrimRight(string(id), "\x00")
+	}
+
+	return net.IP(id).String()
+}
+
+func ntpTime(b []byte) time.Time {
+	seconds := int64(binary.BigEndian.Uint32(b)) - ntpEpochOffset
+	fraction := int64(binary.BigEndian.Uint32(b[4:]))
+
+	return time.Unix(seconds, fraction*int64(time.Second)/ntpFractionScale)
+}
+
+func putNTPTime(b []byte, t time.Time) {
+	seconds := uint64(t.Unix() + ntpEpochOffset)                                //nolint:gosec // NTP era 0 ends in 2036
+	fraction := uint64(t.Nanosecond()) * ntpFractionScale / uint64(time.Second) //nolint:gosec // nanoseconds are never negative
+
+	binary.BigEndian.PutUint32(b, uint32(seconds))      //nolint:gosec // truncated to the NTP era like every client
+	binary.BigEndian.PutUint32(b[4:], uint32(fraction)) //nolint:gosec // fraction is below 2^32
+}
+
+// ntpShortDuration decodes the 16.16 fixed point NTP short format.
+func ntpShortDuration(b []byte) time.Duration {
+	return time.Duration(int64(binary.BigEndian.Uint32(b)) * int64(time.Second) / ntpShortScale)
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNTPServer answers like a stratum 2 server whose clock is off by skew.
+func fakeNTPServer(skew time.Duration, stratum byte) func([]byte) []byte {
+	return func(request []byte) []byte {
+		now := time.Now().Add(skew)
+
+		reply := make([]byte, ntpPacketSize)
+		reply[0] = 0x24 // LI 0, version 4, mode 4 (server)
+		reply[ntpOffsetStratum] = stratum
+		binary.BigEndian.PutUint32(reply[ntpOffsetRootDelay:], 1<<15) // 0.5s
+		copy(reply[ntpOffsetRefID:], []byte{192, 0, 2, 1})
+
+		if stratum == 0 {
+			copy(reply[ntpOffsetRefID:], "RATE")
+		}
+
+		copy(reply[ntpOffsetOrigin:], request[ntpOffsetTransmit:ntpPacketSize])
+		putNTPTime(reply[ntpOffsetReceive:], now)
+		putNTPTime(reply[ntpOffsetTransmit:], now)
+
+		return reply
+	}
+}
+
+func TestNewNTPChecker(t *testing.T) {
+	c, err := NewNTPChecker("pool.ntp.org, 10.0.0.1:1123")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pool.ntp.org:123", "10.0.0.1:1123"}, c.config.Servers)
+	assert.Equal(t, Duration(defaultNTPMaxOffset), c.config.MaxOffset)
+
+	c, err = NewNTPChecker(`{"servers": ["time.example.com"], "max_offset": "100ms"}`)
+	require.NoError(t, err)
+	assert.Equal(t, Duration(100*time.Millisecond), c.config.MaxOffset)
+
+	_, err = NewNTPChecker("")
+	require.ErrorIs(t, err, errDetailsRequiredNTP)
+}
+
+func TestNTPCheckerCheck(t *testing.T) {
+	inSync := startFakeUDPServer(t, fakeNTPServer(0, 2))
+	ahead := startFakeUDPServer(t, fakeNTPServer(2*time.Second, 2))
+	kissOfDeath := startFakeUDPServer(t, fakeNTPServer(0, 0))
+	closed := closedUDPPort(t)
+
+	tests := []struct {
+		name          string
+		servers       []string
+		wantOK        bool
+		wantReachable int
+		wantOffset    time.Duration
+	}{
+		{name: "in sync", servers: []string{inSync}, wantOK: true, wantReachable: 1},
+		{name: "clock behind", servers: []string{ahead}, wantOK: false, wantReachable: 1, wantOffset: 2 * time.Second},
+		{name: "one server down", servers: []string{closed, inSync}, wantOK: true, wantReachable: 1},
+		{name: "kiss of death", servers: []string{kissOfDeath}, wantOK: false},
+		{name: "all unreachable", servers: []string{closed}, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			servers, _ := json.Marshal(tt.servers)
+
+			c, err := NewNTPChecker(fmt.Sprintf(`{"servers": %s, "timeout": "200ms"}`, servers))
+			require.NoError(t, err)
+
+			ok, msg := c.Check(context.Background())
+			assert.Equal(t, tt.wantOK, ok, msg)
+
+			var resp NTPResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+			assert.Equal(t, tt.wantReachable, resp.Reachable)
+			assert.Len(t, resp.Servers, len(tt.servers))
+			assert.InDelta(t, tt.wantOffset.Seconds(), time.Duration(resp.Offset).Seconds(), 0.05)
+
+			if tt.wantReachable > 0 {
+				assert.Equal(t, 2, resp.Stratum)
+				assert.Equal(t, (500 * time.Millisecond).Nanoseconds(), resp.RootDelay)
+			} else {
+				assert.NotEmpty(t, resp.Error)
+			}
+		})
+	}
+}
+
+func TestNTPTimeRoundTrip(t *testing.T) {
+	want := time.Date(2025, 3, 14, 15, 9, 26, 535897000, time.UTC)
+	buf := make([]byte, 8)
+
+	putNTPTime(buf, want)
+	assert.WithinDuration(t, want, ntpTime(buf), time.Microsecond)
+}
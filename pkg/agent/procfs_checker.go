@@ -0,0 +1,308 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/procfs_checker.go
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultProcRoot = "/proc"
+	// clockTicksPerSecond is USER_HZ, which is 100 on all mainstream Linux architectures.
+	clockTicksPerSecond = 100
+	// Field offsets in /proc/<pid>/stat, counted from the state field that follows "(comm)".
+	statFieldUTime     = 11
+	statFieldSTime     = 12
+	statFieldStartTime = 19
+	statFieldRSS       = 21
+)
+
+// ProcfsChecker finds processes by scanning /proc instead of asking systemd,
+// which also works inside containers and on hosts without systemd.
+type ProcfsChecker struct {
+	config   ProcessCheckConfig
+	cmdline  *regexp.Regexp
+	uid      string
+	procRoot string
+}
+
+// ProcessInfo describes a single matched process.
+type ProcessInfo struct {
+	PID           int     `json:"pid"`
+	Name          string  `json:"name"`
+	UID           string  `json:"uid"`
+	RSSBytes      uint64  `json:"rss_bytes"`
+	CPUSeconds    float64 `json:"cpu_seconds"`
+	UptimeSeconds float64 `json:"uptime_seconds"`
+}
+
+// ProcessResponse defines the structure of the procfs process check result.
+type ProcessResponse struct {
+	Strategy      string        `json:"strategy"`
+	Name          string        `json:"name,omitempty"`
+	PIDCount      int           `json:"pid_count"`
+	PIDs          []int         `json:"pids"`
+	RSSBytes      uint64        `json:"rss_bytes"`
+	CPUSeconds    float64       `json:"cpu_seconds"`
+	UptimeSeconds float64       `json:"uptime_seconds"` // uptime of the longest running match
+	Processes     []ProcessInfo `json:"processes,omitempty"`
+	Available     bool          `json:"available"`
+	Error         string        `json:"error,omitempty"`
+}
+
+// NewProcfsChecker creates a ProcfsChecker that matches processes by name,
+// command line, pid file and/or user.
+func NewProcfsChecker(cfg *ProcessCheckConfig) (*ProcfsChecker, error) {
+	if cfg.Name == "" && cfg.CmdlineRegex == "" && cfg.PIDFile == "" && cfg.User == "" {
+		return nil, errNoProcessMatcher
+	}
+
+	c := &ProcfsChecker{config: *cfg, procRoot: defaultProcRoot}
+
+	if c.config.MinCount == 0 {
+		c.config.MinCount = 1
+	}
+
+	if cfg.CmdlineRegex != "" {
+		re, err := regexp.Compile(cfg.CmdlineRegex)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidCmdlineRegex, err)
+		}
+
+		c.cmdline = re
+	}
+
+	if cfg.User != "" {
+		uid, err := lookupUID(cfg.User)
+		if err != nil {
+			return nil, err
+		}
+
+		c.uid = uid
+	}
+
+	return c, nil
+}
+
+func lookupUID(name string) (string, error) {
+	if _, err := strconv.Atoi(name); err == nil {
+		return name, nil
+	}
+
+	u, err := user.Lookup(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up user %s: %w", name, err)
+	}
+
+	return u.Uid, nil
+}
+
+// Check scans the process table and reports the matched processes.
+func (c *ProcfsChecker) Check(_ context.Context) (isActive bool, statusMsg string) {
+	resp := &ProcessResponse{
+		Strategy: processStrategyProcfs,
+		Name:     c.config.Name,
+		PIDs:     []int{},
+	}
+
+	if err := c.scan(resp); err != nil {
+		resp.Error = err.Error()
+	} else if resp.PIDCount < c.config.MinCount {
+		resp.Error = fmt.Sprintf("%v: found %d, expected at least %d", errProcessNotFound, resp.PIDCount, c.config.MinCount)
+	} else {
+		resp.Available = true
+	}
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal process response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+func (c *ProcfsChecker) scan(resp *ProcessResponse) error {
+	pids, err := c.candidatePIDs()
+	if err != nil {
+		return err
+	}
+
+	uptime, err := c.systemUptime()
+	if err != nil {
+		return err
+	}
+
+	for _, pid := range pids {
+		info, ok := c.inspect(pid, uptime)
+		if !ok {
+			continue
+		}
+
+		resp.Processes = append(resp.Processes, *info)
+		resp.PIDs = append(resp.PIDs, info.PID)
+		resp.RSSBytes += info.RSSBytes
+		resp.CPUSeconds += info.CPUSeconds
+
+		if info.UptimeSeconds > resp.UptimeSeconds {
+			resp.UptimeSeconds = info.UptimeSeconds
+		}
+	}
+
+	resp.PIDCount = len(resp.PIDs)
+
+	return nil
+}
+
+// candidatePIDs returns the pid from the pid file when one is configured,
+// otherwise every pid in the process table.
+func (c *ProcfsChecker) candidatePIDs() ([]int, error) {
+	if c.config.PIDFile != "" {
+		data, err := os.ReadFile(c.config.PIDFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pid file: %w", err)
+		}
+
+		pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidPIDFile, c.config.PIDFile)
+		}
+
+		return []int{pid}, nil
+	}
+
+	entries, err := os.ReadDir(c.procRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", c.procRoot, err)
+	}
+
+	pids := make([]int, 0, len(entries))
+
+	for _, entry := range entries {
+		if pid, err := strconv.Atoi(entry.Name()); err == nil && entry.IsDir() {
+			pids = append(pids, pid)
+		}
+	}
+
+	return pids, nil
+}
+
+func (c *ProcfsChecker) systemUptime() (float64, error) {
+	data, err := os.ReadFile(filepath.Join(c.procRoot, "uptime"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read uptime: %w", err)
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, errInvalidProcData
+	}
+
+	return strconv.ParseFloat(fields[0], 64)
+}
+
+// inspect reads a process from procfs and reports whether it matches. Processes
+// that exit while being read are silently skipped.
+func (c *ProcfsChecker) inspect(pid int, systemUptime float64) (*ProcessInfo, bool) {
+	dir := filepath.Join(c.procRoot, strconv.Itoa(pid))
+
+	stat, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return nil, false
+	}
+
+	comm, fields, ok := parseProcStat(stat)
+	if !ok {
+		return nil, false
+	}
+
+	cmdline, _ := os.ReadFile(filepath.Join(dir, "cmdline"))
+	args := strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+
+	if c.config.Name != "" && comm != c.config.Name && filepath.Base(args[0]) != c.config.Name {
+		return nil, false
+	}
+
+	if c.cmdline != nil && !c.cmdline.MatchString(strings.Join(args, " ")) {
+		return nil, false
+	}
+
+	uid := readProcUID(dir)
+	if c.uid != "" && uid != c.uid {
+		return nil, false
+	}
+
+	utime, _ := strconv.ParseUint(fields[statFieldUTime], 10, 64)
+	stime, _ := strconv.ParseUint(fields[statFieldSTime], 10, 64)
+	start, _ := strconv.ParseUint(fields[statFieldStartTime], 10, 64)
+	rss, _ := strconv.ParseUint(fields[statFieldRSS], 10, 64)
+
+	return &ProcessInfo{
+		PID:           pid,
+		Name:          comm,
+		UID:           uid,
+		RSSBytes:      rss * uint64(os.Getpagesize()), //nolint:gosec // page size is always positive
+		CPUSeconds:    float64(utime+stime) / clockTicksPerSecond,
+		UptimeSeconds: systemUptime - float64(start)/clockTicksPerSecond,
+	}, true
+}
+
+// parseProcStat splits /proc/<pid>/stat into the command name and the
+// fields following it. The name is parenthesised and may contain spaces.
+func parseProcStat(stat []byte) (comm string, fields []string, ok bool) {
+	open := bytes.IndexByte(stat, '(')
+	closing := bytes.LastIndexByte(stat, ')')
+
+	if open < 0 || closing < open {
+		return "", nil, false
+	}
+
+	fields = strings.Fields(string(stat[closing+1:]))
+	if len(fields) <= statFieldRSS {
+		return "", nil, false
+	}
+
+	return string(stat[open+1 : closing]), fields, true
+}
+
+// readProcUID returns the real uid from /proc/<pid>/status.
+func readProcUID(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, "status"))
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) > 1 && fields[0] == "Uid:" {
+			return fields[1]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFakeProc creates a minimal procfs tree with the given processes.
+func writeFakeProc(t *testing.T, procs map[int][3]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "uptime"), []byte("1000.00 4000.00\n"), 0600))
+
+	for pid, p := range procs {
+		comm, cmdline, uid := p[0], p[1], p[2]
+		dir := filepath.Join(root, strconv.Itoa(pid))
+		require.NoError(t, os.MkdirAll(dir, 0700))
+
+		// utime=300 stime=200 starttime=50000 (500s after boot) rss=10 pages
+		stat := fmt.Sprintf("%d (%s) S 1 1 1 0 -1 0 0 0 0 0 300 200 0 0 20 0 1 0 50000 1000000 10 0", pid, comm)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "cmdline"),
+			[]byte(strings.ReplaceAll(cmdline, " ", "\x00")+"\x00"), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "status"),
+			[]byte(fmt.Sprintf("Name:\t%s\nUid:\t%s\t%s\t%s\t%s\n", comm, uid, uid, uid, uid)), 0600))
+	}
+
+	return root
+}
+
+func TestNewProcessChecker(t *testing.T) {
+	tests := []struct {
+		name      string
+		details   string
+		wantType  interface{}
+		wantError bool
+	}{
+		{name: "plain unit name", details: "nginx", wantType: &ProcessChecker{}},
+		{name: "systemd strategy", details: `{"strategy": "systemd", "name": "nginx"}`, wantType: &ProcessChecker{}},
+		{name: "procfs strategy", details: `{"strategy": "procfs", "name": "nginx"}`, wantType: &ProcfsChecker{}},
+		{name: "procfs without matcher", details: `{"strategy": "procfs"}`, wantError: true},
+		{name: "unknown strategy", details: `{"strategy": "launchd", "name": "nginx"}`, wantError: true},
+		{name: "invalid regex", details: `{"strategy": "procfs", "cmdline_regex": "("}`, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewProcessChecker(tt.details)
+			if tt.wantError {
+				require.Error(t, err)
+				assert.Nil(t, c)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.IsType(t, tt.wantType, c)
+		})
+	}
+}
+
+func TestProcfsCheckerCheck(t *testing.T) {
+	root := writeFakeProc(t, map[int][3]string{
+		100: {"nginx", "nginx: master process /usr/sbin/nginx", "0"},
+		101: {"nginx", "nginx: worker process", "33"},
+		200: {"java", "/usr/bin/java -jar /opt/app/service.jar", "1000"},
+		300: {"my worker", "/opt/bin/my-worker --queue jobs", "1000"},
+	})
+
+	pidFile := filepath.Join(t.TempDir(), "app.pid")
+	require.NoError(t, os.WriteFile(pidFile, []byte("200\n"), 0600))
+
+	tests := []struct {
+		name     string
+		config   ProcessCheckConfig
+		wantOK   bool
+		wantPIDs []int
+	}{
+		{name: "by name", config: ProcessCheckConfig{Name: "nginx"}, wantOK: true, wantPIDs: []int{100, 101}},
+		{name: "by argv0 basename", config: ProcessCheckConfig{Name: "my-worker"}, wantOK: true, wantPIDs: []int{300}},
+		{name: "by cmdline", config: ProcessCheckConfig{CmdlineRegex: `service\.jar`}, wantOK: true, wantPIDs: []int{200}},
+		{name: "by name and user", config: ProcessCheckConfig{Name: "nginx", User: "33"}, wantOK: true, wantPIDs: []int{101}},
+		{name: "by pid file", config: ProcessCheckConfig{PIDFile: pidFile}, wantOK: true, wantPIDs: []int{200}},
+		{name: "min count not met", config: ProcessCheckConfig{Name: "nginx", MinCount: 3}, wantOK: false, wantPIDs: []int{100, 101}},
+		{name: "no match", config: ProcessCheckConfig{Name: "postgres"}, wantOK: false, wantPIDs: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewProcfsChecker(&tt.config)
+			require.NoError(t, err)
+
+			c.procRoot = root
+
+			ok, msg := c.Check(context.Background())
+			assert.Equal(t, tt.wantOK, ok, msg)
+
+			var resp ProcessResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+			assert.ElementsMatch(t, tt.wantPIDs, resp.PIDs)
+			assert.Equal(t, len(tt.wantPIDs), resp.PIDCount)
+
+			for _, p := range resp.Processes {
+				assert.InDelta(t, 5.0, p.CPUSeconds, 0.001)
+				assert.InDelta(t, 500.0, p.UptimeSeconds, 0.001)
+				assert.Equal(t, uint64(10*os.Getpagesize()), p.RSSBytes)
+			}
+		})
+	}
+}
+
+func TestParseProcStat(t *testing.T) {
+	comm, fields, ok := parseProcStat([]byte("42 (tricky) name) R 1 1 1 0 -1 0 0 0 0 0 7 8 0 0 20 0 1 0 99 0 5 0"))
+	require.True(t, ok)
+	assert.Equal(t, "tricky) name", comm)
+	assert.Equal(t, "7", fields[statFieldUTime])
+	assert.Equal(t, "99", fields[statFieldStartTime])
+	assert.Equal(t, "5", fields[statFieldRSS])
+
+	_, _, ok = parseProcStat([]byte("42 (short) R 1"))
+	assert.False(t, ok)
+}
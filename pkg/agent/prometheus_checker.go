@@ -0,0 +1,508 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/prometheus_checker.go
+package agent
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPrometheusTimeout = 10 * time.Second
+	maxMetricsBodyBytes      = 16 << 20 // exporters with more series should be filtered upstream
+	maxMetricsLineBytes      = 1 << 20
+	maxForwardedSeries       = 1000
+
+	prometheusAcceptHeader = "application/openmetrics-text;version=1.0.0,text/plain;version=0.0.4;q=0.5,*/*;q=0.1"
+)
+
+// MetricSelector selects series by metric name and label values. Both the
+// name and the label values are regular expressions matched against the
+// whole string, so plain names and values match exactly.
+type MetricSelector struct {
+	Metric string            `json:"metric"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// MetricAssertion requires at least one series to match the selector and
+// every matching series to lie within the optional Above and Below bounds.
+type MetricAssertion struct {
+	MetricSelector
+	Above *float64 `json:"above,omitempty"`
+	Below *float64 `json:"below,omitempty"`
+}
+
+// PrometheusCheckerConfig describes the endpoint a PrometheusChecker scrapes,
+// the assertions that decide availability and the series forwarded to core.
+type PrometheusCheckerConfig struct {
+	URL                string            `json:"url"`
+	Headers            map[string]string `json:"headers,omitempty"`
+	Assertions         []MetricAssertion `json:"assertions,omitempty"`
+	Forward            []MetricSelector  `json:"forward,omitempty"`
+	InsecureSkipVerify bool              `json:"insecure_skip_verify,omitempty"`
+	Timeout            Duration          `json:"timeout,omitempty"`
+}
+
+// PrometheusChecker scrapes a Prometheus or OpenMetrics text endpoint.
+type PrometheusChecker struct {
+	config     PrometheusCheckerConfig
+	assertions []*seriesMatcher
+	forward    []*seriesMatcher
+	client     *http.Client
+}
+
+// MetricSeries is a single scraped sample.
+type MetricSeries struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Value  float64           `json:"value"`
+}
+
+// AssertionResult reports the outcome of one MetricAssertion. Value holds the
+// first offending sample, or the first matching one when the assertion passed.
+type AssertionResult struct {
+	Metric  string            `json:"metric"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Matched int               `json:"matched"`
+	Value   *float64          `json:"value,omitempty"`
+	Passed  bool              `json:"passed"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// PrometheusResponse defines the structure of the Prometheus check result.
+// Series holds the forwarded samples that core stores as timeseries metrics.
+type PrometheusResponse struct {
+	URL             string            `json:"url"`
+	StatusCode      int               `json:"status_code,omitempty"`
+	SeriesCount     int               `json:"series_count"`
+	Assertions      []AssertionResult `json:"assertions,omitempty"`
+	Series          []MetricSeries    `json:"series,omitempty"`
+	SeriesTruncated bool              `json:"series_truncated,omitempty"`
+	ResponseTime    int64             `json:"response_time"`
+	Available       bool              `json:"available"`
+	Error           string            `json:"error,omitempty"`
+}
+
+type seriesMatcher struct {
+	name   *regexp.Regexp
+	labels map[string]*regexp.Regexp
+}
+
+// NewPrometheusChecker creates a PrometheusChecker from the check details,
+// which are either a plain URL or a JSON encoded PrometheusCheckerConfig.
+// Without assertions the check passes whenever the endpoint can be scraped.
+func NewPrometheusChecker(details string) (*PrometheusChecker, error) {
+	details = strings.TrimSpace(details)
+	if details == "" {
+		return nil, errDetailsRequiredPrometheus
+	}
+
+	var cfg PrometheusCheckerConfig
+
+	if strings.HasPrefix(details, "{") {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse prometheus check details: %w", err)
+		}
+	} else {
+		cfg.URL = details
+	}
+
+	u, err := url.Parse(cfg.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("%w: %q", errInvalidURL, cfg.URL)
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = Duration(defaultPrometheusTimeout)
+	}
+
+	c := &PrometheusChecker{config: cfg}
+
+	for i := range cfg.Assertions {
+		m, err := newSeriesMatcher(&cfg.Assertions[i].MetricSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		c.assertions = append(c.assertions, m)
+	}
+
+	for i := range cfg.Forward {
+		m, err := newSeriesMatcher(&cfg.Forward[i])
+		if err != nil {
+			return nil, err
+		}
+
+		c.forward = append(c.forward, m)
+	}
+
+	c.client = &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // opt-in for self-signed endpoints
+			},
+		},
+	}
+
+	return c, nil
+}
+
+func newSeriesMatcher(selector *MetricSelector) (*seriesMatcher, error) {
+	if selector.Metric == "" {
+		return nil, errMetricNameRequired
+	}
+
+	name, err := regexp.Compile("^(?:" + selector.Metric + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidMetricSelector, err)
+	}
+
+	m := &seriesMatcher{name: name, labels: make(map[string]*regexp.Regexp, len(selector.Labels))}
+
+	for label, pattern := range selector.Labels {
+		if m.labels[label], err = regexp.Compile("^(?:" + pattern + ")$"); err != nil {
+			return nil, fmt.Errorf("%w: label %s: %w", errInvalidMetricSelector, label, err)
+		}
+	}
+
+	return m, nil
+}
+
+// matches reports whether a series has the selected name and labels. A
+// missing label matches a pattern that accepts the empty string, as in PromQL.
+func (m *seriesMatcher) matches(series *MetricSeries) bool {
+	if !m.name.MatchString(series.Name) {
+		return false
+	}
+
+	for label, re := range m.labels {
+		if !re.MatchString(series.Labels[label]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Check scrapes the endpoint, evaluates the assertions and selects the
+// series to forward.
+func (c *PrometheusChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	resp := &PrometheusResponse{URL: c.config.URL}
+
+	start := time.Now()
+
+	series, err := c.scrape(ctx, resp)
+
+	resp.ResponseTime = time.Since(start).Nanoseconds()
+
+	if err == nil {
+		resp.SeriesCount = len(series)
+		c.forwardSeries(series, resp)
+		err = c.evaluate(series, resp)
+	}
+
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Available = true
+	}
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal prometheus response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+func (c *PrometheusChecker) scrape(ctx context.Context, resp *PrometheusResponse) ([]MetricSeries, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout))
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.URL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", prometheusAcceptHeader)
+
+	for key, value := range c.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	r, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scrape failed: %w", err)
+	}
+	defer func() { _ = r.Body.Close() }()
+
+	resp.StatusCode = r.StatusCode
+
+	if r.StatusCode < http.StatusOK || r.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("%w: %d", errUnexpectedStatus, r.StatusCode)
+	}
+
+	return parseMetricsText(io.LimitReader(r.Body, maxMetricsBodyBytes))
+}
+
+func (c *PrometheusChecker) forwardSeries(series []MetricSeries, resp *PrometheusResponse) {
+	for i := range series {
+		// NaN and infinities cannot be encoded as JSON numbers.
+		if math.IsNaN(series[i].Value) || math.IsInf(series[i].Value, 0) {
+			continue
+		}
+
+		for _, m := range c.forward {
+			if !m.matches(&series[i]) {
+				continue
+			}
+
+			if len(resp.Series) == maxForwardedSeries {
+				resp.SeriesTruncated = true
+
+				return
+			}
+
+			resp.Series = append(resp.Series, series[i])
+
+			break
+		}
+	}
+}
+
+// evaluate runs every assertion and returns an error naming the first one
+// that failed.
+func (c *PrometheusChecker) evaluate(series []MetricSeries, resp *PrometheusResponse) error {
+	var failed error
+
+	for i, m := range c.assertions {
+		assertion := &c.config.Assertions[i]
+		result := AssertionResult{Metric: assertion.Metric, Labels: assertion.Labels, Passed: true}
+
+		for j := range series {
+			if !m.matches(&series[j]) {
+				continue
+			}
+
+			result.Matched++
+
+			if result.Passed && !assertion.within(series[j].Value) {
+				result.Passed = false
+				result.Value = finiteValue(series[j].Value)
+				result.Error = fmt.Sprintf("%s = %v is out of bounds", formatSeries(&series[j]), series[j].Value)
+			} else if result.Matched == 1 {
+				result.Value = finiteValue(series[j].Value)
+			}
+		}
+
+		if result.Matched == 0 {
+			result.Passed = false
+			result.Error = "no matching series"
+		}
+
+		if !result.Passed && failed == nil {
+			failed = fmt.Errorf("%w: %s: %s", errMetricAssertionFailed, assertion.Metric, result.Error)
+		}
+
+		resp.Assertions = append(resp.Assertions, result)
+	}
+
+	return failed
+}
+
+func (a *MetricAssertion) within(value float64) bool {
+	if a.Above != nil && !(value > *a.Above) {
+		return false
+	}
+
+	if a.Below != nil && !(value < *a.Below) {
+		return false
+	}
+
+	return true
+}
+
+func finiteValue(value float64) *float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+
+	return &value
+}
+
+// formatSeries renders a series in the exposition format, with labels sorted.
+func formatSeries(series *MetricSeries) string {
+	if len(series.Labels) == 0 {
+		return series.Name
+	}
+
+	labels := make([]string, 0, len(series.Labels))
+
+	for name, value := range series.Labels {
+		labels = append(labels, name+"="+strconv.Quote(value))
+	}
+
+	sort.Strings(labels)
+
+	return series.Name + "{" + strings.Join(labels, ",") + "}"
+}
+
+// parseMetricsText parses the Prometheus text exposition format and its
+// OpenMetrics successor. Comments, type metadata, timestamps and exemplars
+// are ignored; only names, labels and values are kept.
+func parseMetricsText(r io.Reader) ([]MetricSeries, error) {
+	var series []MetricSeries
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxMetricsLineBytes)
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		sample, err := parseMetricsLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", errInvalidMetricsText, lineNo, err)
+		}
+
+		series = append(series, sample)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read metrics: %w", err)
+	}
+
+	return series, nil
+}
+
+// parseMetricsLine parses `name{label="value",...} value [timestamp] [# exemplar]`.
+func parseMetricsLine(line string) (MetricSeries, error) {
+	var sample MetricSeries
+
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return sample, errMissingMetricValue
+	}
+
+	sample.Name, line = line[:end], line[end:]
+
+	if line[0] == '{' {
+		labels, rest, err := parseMetricLabels(line[1:])
+		if err != nil {
+			return sample, err
+		}
+
+		sample.Labels, line = labels, rest
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return sample, errMissingMetricValue
+	}
+
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, fmt.Errorf("%w %q for %s", errInvalidMetricValue, fields[0], sample.Name)
+	}
+
+	sample.Value = value
+
+	return sample, nil
+}
+
+// parseMetricLabels parses a label set up to and including the closing
+// brace and returns the remainder of the line.
+func parseMetricLabels(s string) (labels map[string]string, rest string, err error) {
+	labels = make(map[string]string)
+
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		if s == "" {
+			return nil, "", errUnterminatedLabels
+		}
+
+		if s[0] == '}' {
+			return labels, s[1:], nil
+		}
+
+		name, value, found := strings.Cut(s, "=")
+		if !found {
+			return nil, "", errUnterminatedLabels
+		}
+
+		value = strings.TrimLeft(value, " \t")
+		if value == "" || value[0] != '"' {
+			return nil, "", fmt.Errorf("%w: label %s is not quoted", errInvalidMetricsText, strings.TrimSpace(name))
+		}
+
+		unquoted, n, err := unquoteLabelValue(value[1:])
+		if err != nil {
+			return nil, "", err
+		}
+
+		labels[strings.TrimSpace(name)] = unquoted
+		s = value[1+n:]
+	}
+}
+
+// unquoteLabelValue reads a label value up to its closing quote, resolving the
+// \\, \" and \n escapes, and returns the number of bytes consumed.
+func unquoteLabelValue(s string) (value string, consumed int, err error) {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			i++
+
+			if i == len(s) {
+				return "", 0, errUnterminatedLabels
+			}
+
+			if s[i] == 'n' {
+				b.WriteByte('\n')
+			} else {
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return "", 0, errUnterminatedLabels
+}
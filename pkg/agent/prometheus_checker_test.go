@@ -0,0 +1,190 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetricsText = `# HELP up Whether the target is up.
+# TYPE up gauge
+up{job="api",instance="10.0.0.1:9100"} 1
+up{job="db",instance="10.0.0.2:9100"} 0 1712345678000
+# TYPE http_requests_total counter
+http_requests_total{method="GET",path="/a \"quoted\" \\ path\n"} 1027 # {trace_id="abc"} 1.0
+http_requests_total{method="POST",} 3
+queue_depth 42.5
+temperature NaN
+# EOF
+`
+
+func TestParseMetricsText(t *testing.T) {
+	series, err := parseMetricsText(strings.NewReader(testMetricsText))
+	require.NoError(t, err)
+	require.Len(t, series, 6)
+
+	assert.Equal(t, MetricSeries{
+		Name:   "up",
+		Labels: map[string]string{"job": "db", "instance": "10.0.0.2:9100"},
+	}, series[1])
+	assert.Equal(t, "/a \"quoted\" \\ path\n", series[2].Labels["path"])
+	assert.InDelta(t, 1027, series[2].Value, 0)
+	assert.Equal(t, map[string]string{"method": "POST"}, series[3].Labels)
+	assert.Equal(t, MetricSeries{Name: "queue_depth", Value: 42.5}, series[4])
+	assert.True(t, math.IsNaN(series[5].Value))
+}
+
+func TestParseMetricsTextInvalid(t *testing.T) {
+	for _, line := range []string{
+		`up`,
+		`up{job="api"`,
+		`up{job=api} 1`,
+		`up{job="api} 1`,
+		`up{job="api"} one`,
+	} {
+		_, err := parseMetricsText(strings.NewReader(line))
+		require.ErrorIs(t, err, errInvalidMetricsText, line)
+	}
+}
+
+func TestNewPrometheusChecker(t *testing.T) {
+	c, err := NewPrometheusChecker("http://localhost:9100/metrics")
+	require.NoError(t, err)
+	assert.Equal(t, Duration(defaultPrometheusTimeout), c.config.Timeout)
+
+	_, err = NewPrometheusChecker("")
+	require.ErrorIs(t, err, errDetailsRequiredPrometheus)
+
+	_, err = NewPrometheusChecker("localhost:9100")
+	require.ErrorIs(t, err, errInvalidURL)
+
+	_, err = NewPrometheusChecker(`{"url": "http://localhost/metrics", "assertions": [{"labels": {"job": "api"}}]}`)
+	require.ErrorIs(t, err, errMetricNameRequired)
+
+	_, err = NewPrometheusChecker(`{"url": "http://localhost/metrics", "forward": [{"metric": "up", "labels": {"job": "("}}]}`)
+	require.ErrorIs(t, err, errInvalidMetricSelector)
+}
+
+func TestPrometheusCheckerCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = fmt.Fprint(w, testMetricsText)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		assertions string
+		wantOK     bool
+		wantError  string
+	}{
+		{"no assertions", `[]`, true, ""},
+		{"metric exists", `[{"metric": "queue_depth"}]`, true, ""},
+		{"metric missing", `[{"metric": "missing_metric"}]`, false, "no matching series"},
+		{"label match", `[{"metric": "up", "labels": {"job": "api"}, "above": 0}]`, true, ""},
+		{"label regex", `[{"metric": "up", "labels": {"job": "api|db"}, "above": 0}]`, false, `up{instance="10.0.0.2:9100",job="db"} = 0`},
+		{"below threshold", `[{"metric": "queue_depth", "below": 100}]`, true, ""},
+		{"above threshold", `[{"metric": "queue_depth", "below": 10}]`, false, "queue_depth = 42.5"},
+		{"name regex", `[{"metric": "http_.*", "above": 1}]`, true, ""},
+		{"NaN fails bounds", `[{"metric": "temperature", "below": 100}]`, false, "temperature = NaN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			details := fmt.Sprintf(`{"url": %q, "assertions": %s}`, server.URL+"/metrics", tt.assertions)
+
+			c, err := NewPrometheusChecker(details)
+			require.NoError(t, err)
+
+			ok, msg := c.Check(context.Background())
+
+			var resp PrometheusResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+
+			assert.Equal(t, tt.wantOK, ok, msg)
+			assert.Equal(t, tt.wantOK, resp.Available)
+			assert.Equal(t, 6, resp.SeriesCount)
+			assert.Contains(t, resp.Error, tt.wantError)
+
+			if !tt.wantOK {
+				assert.Contains(t, resp.Error, errMetricAssertionFailed.Error())
+			}
+		})
+	}
+}
+
+func TestPrometheusCheckerForward(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, testMetricsText)
+	}))
+	defer server.Close()
+
+	details := fmt.Sprintf(`{"url": %q, "forward": [
+		{"metric": "up"},
+		{"metric": "up", "labels": {"job": "api"}},
+		{"metric": "temperature"},
+		{"metric": "http_requests_total", "labels": {"method": "POST"}}
+	]}`, server.URL)
+
+	c, err := NewPrometheusChecker(details)
+	require.NoError(t, err)
+
+	ok, msg := c.Check(context.Background())
+	require.True(t, ok, msg)
+
+	var resp PrometheusResponse
+	require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+
+	// Each series is forwarded once and NaN samples are dropped.
+	require.Len(t, resp.Series, 3)
+	assert.Equal(t, "api", resp.Series[0].Labels["job"])
+	assert.Equal(t, "db", resp.Series[1].Labels["job"])
+	assert.Equal(t, MetricSeries{Name: "http_requests_total", Labels: map[string]string{"method": "POST"}, Value: 3}, resp.Series[2])
+}
+
+func TestPrometheusCheckerScrapeFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c, err := NewPrometheusChecker(server.URL)
+	require.NoError(t, err)
+
+	ok, msg := c.Check(context.Background())
+	assert.False(t, ok)
+
+	var resp PrometheusResponse
+	require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Contains(t, resp.Error, errUnexpectedStatus.Error())
+}
@@ -0,0 +1,320 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/redis_checker.go
+package agent
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRedisPort  = "6379"
+	defaultRedisProbe = "PING"
+	// maxRedisReplyBytes bounds the total size of a reply; INFO output is a few KiB.
+	maxRedisReplyBytes = 1024 * 1024
+	// maxRedisArrayLen bounds array replies so a malformed length cannot
+	// allocate without limit.
+	maxRedisArrayLen = 64 * 1024
+	// maxRedisReplyDepth bounds how deeply arrays may nest.
+	maxRedisReplyDepth = 8
+)
+
+// RedisChecker logs in to a Redis server, runs the probe command and reads
+// the version and replication role from INFO.
+type RedisChecker struct {
+	config DatabaseCheckerConfig
+}
+
+// NewRedisChecker creates a RedisChecker. SSLMode "require" connects with
+// TLS without verifying the certificate, "verify-ca" and "verify-full" verify it.
+func NewRedisChecker(details string) (*RedisChecker, error) {
+	cfg, err := parseDatabaseDetails(details, defaultRedisPort)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Query == "" {
+		cfg.Query = defaultRedisProbe
+	}
+
+	if cfg.Database != "" {
+		if _, err := strconv.Atoi(cfg.Database); err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidRedisDatabase, cfg.Database)
+		}
+	}
+
+	return &RedisChecker{config: cfg}, nil
+}
+
+// Check connects, authenticates and runs the probe command.
+func (c *RedisChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout))
+	defer cancel()
+
+	resp := &DatabaseResponse{Address: c.config.Address}
+
+	start := time.Now()
+
+	if err := c.probe(ctx, resp); err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Available = true
+	}
+
+	resp.ResponseTime = time.Since(start).Nanoseconds()
+
+	return marshalDatabaseResponse(resp)
+}
+
+func (c *RedisChecker) probe(ctx context.Context, resp *DatabaseResponse) error {
+	start := time.Now()
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+
+	if err := c.login(client); err != nil {
+		return err
+	}
+
+	resp.ConnectTime = time.Since(start).Nanoseconds()
+	start = time.Now()
+
+	if resp.ProbeResult, err = client.do(strings.Fields(c.config.Query)...); err != nil {
+		return fmt.Errorf("probe command failed: %w", err)
+	}
+
+	resp.ProbeTime = time.Since(start).Nanoseconds()
+
+	// INFO may be disabled through rename-command; the probe result decides availability.
+	if info, err := client.do("INFO", "server"); err == nil {
+		resp.Version = redisInfoField(info, "redis_version")
+	}
+
+	if info, err := client.do("INFO", "replication"); err == nil {
+		switch redisInfoField(info, "role") {
+		case "master":
+			resp.Role = databaseRolePrimary
+		case "slave":
+			resp.Role = databaseRoleReplica
+		}
+	}
+
+	return nil
+}
+
+func (c *RedisChecker) dial(ctx context.Context) (net.Conn, error) {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", c.config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	if c.config.SSLMode == "" || c.config.SSLMode == sslModeDisable {
+		return conn, nil
+	}
+
+	host, _, _ := net.SplitHostPort(c.config.Address)
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: c.config.SSLMode == sslModeRequire, //nolint:gosec // explicitly requested by ssl_mode
+		MinVersion:         tls.VersionTLS12,
+	})
+
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("TLS handshake failed: %w", err)
+	}
+
+	return tlsConn, nil
+}
+
+func (c *RedisChecker) login(client *redisConn) error {
+	if c.config.Password != "" {
+		args := []string{"AUTH", c.config.Password}
+		if c.config.Username != "" {
+			args = []string{"AUTH", c.config.Username, c.config.Password}
+		}
+
+		if _, err := client.do(args...); err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+	}
+
+	if c.config.Database != "" {
+		if _, err := client.do("SELECT", c.config.Database); err != nil {
+			return fmt.Errorf("failed to select database: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// redisConn speaks the subset of RESP needed for health checks.
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// do sends a command and returns its reply as a string. Array replies are
+// joined with newlines and error replies are returned as errors.
+func (r *redisConn) do(args ...string) (string, error) {
+	var cmd strings.Builder
+
+	fmt.Fprintf(&cmd, "*%d\r\n", len(args))
+
+	for _, arg := range args {
+		fmt.Fprintf(&cmd, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	if _, err := io.WriteString(r.conn, cmd.String()); err != nil {
+		return "", err
+	}
+
+	return r.readReply()
+}
+
+// readReply reads a single reply, bounding its nesting depth and total size.
+func (r *redisConn) readReply() (string, error) {
+	budget := maxRedisReplyBytes
+
+	return r.readValue(maxRedisReplyDepth, &budget)
+}
+
+// readValue reads one RESP value. depth is the number of array levels still
+// allowed and budget the number of reply bytes left to read.
+func (r *redisConn) readValue(depth int, budget *int) (string, error) {
+	// ReadSlice fails once a line outgrows the reader's buffer instead of growing it.
+	raw, err := r.reader.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		return "", fmt.Errorf("%w: line too long", errInvalidRedisReply)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := spendRedisBudget(budget, len(raw)); err != nil {
+		return "", err
+	}
+
+	line := strings.TrimSuffix(string(raw), "\r\n")
+	if line == "" {
+		return "", errInvalidRedisReply
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", fmt.Errorf("%w: %s", errRedisError, line[1:])
+	case '$':
+		return r.readBulk(line[1:], budget)
+	case '*':
+		if depth == 0 {
+			return "", fmt.Errorf("%w: arrays nested too deeply", errInvalidRedisReply)
+		}
+
+		return r.readArray(line[1:], depth-1, budget)
+	default:
+		return "", fmt.Errorf("%w: %q", errInvalidRedisReply, line)
+	}
+}
+
+func (r *redisConn) readBulk(size string, budget *int) (string, error) {
+	n, err := strconv.Atoi(size)
+	if err != nil {
+		return "", fmt.Errorf("%w: bulk length %s", errInvalidRedisReply, size)
+	}
+
+	if n < 0 {
+		return "", nil // nil bulk string
+	}
+
+	if err := spendRedisBudget(budget, n+len("\r\n")); err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, n+len("\r\n"))
+	if _, err := io.ReadFull(r.reader, buf); err != nil {
+		return "", err
+	}
+
+	return string(buf[:n]), nil
+}
+
+func (r *redisConn) readArray(size string, depth int, budget *int) (string, error) {
+	n, err := strconv.Atoi(size)
+	if err != nil || n > maxRedisArrayLen {
+		return "", fmt.Errorf("%w: array length %s", errInvalidRedisReply, size)
+	}
+
+	// Grow with the elements actually read rather than the announced length.
+	var elements []string
+
+	for i := 0; i < n; i++ {
+		element, err := r.readValue(depth, budget)
+		if err != nil {
+			return "", err
+		}
+
+		elements = append(elements, element)
+	}
+
+	return strings.Join(elements, "\n"), nil
+}
+
+// spendRedisBudget takes n bytes from the remaining reply budget.
+func spendRedisBudget(budget *int, n int) error {
+	if n > *budget {
+		return fmt.Errorf("%w: reply exceeds %d bytes", errInvalidRedisReply, maxRedisReplyBytes)
+	}
+
+	*budget -= n
+
+	return nil
+}
+
+// redisInfoField returns a field from INFO output ("key:value" lines).
+func redisInfoField(info, key string) string {
+	for _, line := range strings.Split(info, "\n") {
+		if value, found := strings.CutPrefix(strings.TrimSpace(line), key+":"); found {
+			return value
+		}
+	}
+
+	return ""
+}
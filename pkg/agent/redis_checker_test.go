@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis answers AUTH, SELECT, PING, INFO and GET over RESP.
+type fakeRedis struct {
+	role string
+}
+
+func (f *fakeRedis) handle(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	authenticated := false
+
+	for {
+		args, err := readRESPCommand(r)
+		if err != nil {
+			return
+		}
+
+		var reply string
+
+		switch cmd := strings.ToUpper(args[0]); {
+		case cmd == "AUTH":
+			authenticated = args[len(args)-1] == fakeDBPassword
+			reply = "+OK\r\n"
+
+			if !authenticated {
+				reply = "-WRONGPASS invalid username-password pair\r\n"
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case cmd == "SELECT":
+			reply = "+OK\r\n"
+		case cmd == "PING":
+			reply = "+PONG\r\n"
+		case cmd == "INFO":
+			info := "# Server\r\nredis_version:7.2.4\r\n"
+			if len(args) > 1 && args[1] == "replication" {
+				info = "# Replication\r\nrole:" + f.role + "\r\nconnected_slaves:0\r\n"
+			}
+
+			reply = fmt.Sprintf("$%d\r\n%s\r\n", len(info), info)
+		case cmd == "GET":
+			reply = "$-1\r\n"
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+
+		_, _ = io.WriteString(conn, reply)
+	}
+}
+
+func readRESPCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		if _, err := r.ReadString('\n'); err != nil { // $<len>
+			return nil, err
+		}
+
+		arg, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, strings.TrimSuffix(arg, "\r\n"))
+	}
+
+	return args, nil
+}
+
+func TestNewRedisChecker(t *testing.T) {
+	c, err := NewRedisChecker("cache.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "cache.example.com:6379", c.config.Address)
+	assert.Equal(t, "PING", c.config.Query)
+
+	_, err = NewRedisChecker(`{"address": "cache", "database": "zero"}`)
+	require.ErrorIs(t, err, errInvalidRedisDatabase)
+}
+
+func TestRedisCheckerCheck(t *testing.T) {
+	primary := startFakeServer(t, (&fakeRedis{role: "master"}).handle)
+	replica := startFakeServer(t, (&fakeRedis{role: "slave"}).handle)
+
+	tests := []struct {
+		name      string
+		details   string
+		wantOK    bool
+		wantProbe string
+		wantRole  string
+		wantError string
+	}{
+		{name: "ping", details: databaseDetails(primary, fakeDBPassword, ""), wantOK: true, wantProbe: "PONG", wantRole: "primary"},
+		{name: "replica", details: databaseDetails(replica, fakeDBPassword, ""), wantOK: true, wantProbe: "PONG", wantRole: "replica"},
+		{name: "custom probe", details: databaseDetails(primary, fakeDBPassword, "GET health"), wantOK: true, wantRole: "primary"},
+		{name: "bad password", details: databaseDetails(primary, "wrong", ""), wantError: "WRONGPASS"},
+		{name: "failing probe", details: databaseDetails(primary, fakeDBPassword, "FLUSHALL"), wantError: "unknown command"},
+		{name: "no auth", details: primary, wantError: "NOAUTH"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewRedisChecker(tt.details)
+			require.NoError(t, err)
+
+			ok, msg := c.Check(context.Background())
+			assert.Equal(t, tt.wantOK, ok, msg)
+
+			var resp DatabaseResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+			assert.Equal(t, tt.wantProbe, resp.ProbeResult)
+			assert.Equal(t, tt.wantRole, resp.Role)
+			assert.Contains(t, resp.Error, tt.wantError)
+
+			if tt.wantOK {
+				assert.Equal(t, "7.2.4", resp.Version)
+			}
+		})
+	}
+}
+
+func TestRedisReplyLimits(t *testing.T) {
+	half := maxRedisReplyBytes / 2
+	halfBulk := fmt.Sprintf("$%d\r\n%s\r\n", half, strings.Repeat("x", half))
+
+	tests := []struct {
+		name    string
+		reply   string
+		want    string
+		wantErr error
+	}{
+		{name: "array", reply: "*2\r\n+a\r\n:1\r\n", want: "a\n1"},
+		{name: "nil array", reply: "*-1\r\n"},
+		{name: "huge array", reply: "*9999999999999\r\n", wantErr: errInvalidRedisReply},
+		{name: "huge bulk", reply: "$9999999999999\r\n", wantErr: errInvalidRedisReply},
+		{name: "nested array", reply: "*1\r\n*1\r\n+a\r\n", want: "a"},
+		{name: "deeply nested array", reply: strings.Repeat("*1\r\n", maxRedisReplyDepth+1) + "+a\r\n", wantErr: errInvalidRedisReply},
+		{name: "bulks over the reply budget", reply: "*2\r\n" + halfBulk + halfBulk, wantErr: errInvalidRedisReply},
+		{name: "unterminated line", reply: "+" + strings.Repeat("x", 8192), wantErr: errInvalidRedisReply},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &redisConn{reader: bufio.NewReader(strings.NewReader(tt.reply))}
+
+			got, err := r.readReply()
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -23,7 +23,7 @@ import (
 	"github.com/carverauto/serviceradar/pkg/checker"
 )
 
-func initRegistry() checker.Registry {
+func initRegistry(s *Server) checker.Registry {
 	registry := checker.NewRegistry()
 
 	// Register the process checker
@@ -32,7 +32,7 @@ func initRegistry() checker.Registry {
 			details = serviceName // Fallback to service name if details empty
 		}
 
-		return &ProcessChecker{ProcessName: details}, nil
+		return NewProcessChecker(details)
 	})
 
 	// Register the port checker
@@ -56,7 +56,7 @@ func initRegistry() checker.Registry {
 			return nil, errDetailsRequiredGRPC
 		}
 
-		return NewExternalChecker(ctx, serviceName, "grpc", details)
+		return NewExternalChecker(ctx, serviceName, "grpc", details, s.externalCheckerSecurity(serviceName, details))
 	})
 
 	// Register the SNMP checker
@@ -68,5 +68,83 @@ func initRegistry() checker.Registry {
 		return NewSNMPChecker(ctx, details)
 	})
 
+	// Register the HTTP checker
+	registry.Register("http", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewHTTPChecker(details)
+	})
+
+	// Register the TLS certificate checker
+	registry.Register("tls", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewTLSChecker(details)
+	})
+
+	// Register the DNS checker
+	registry.Register("dns", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewDNSChecker(details)
+	})
+
+	// Register the host resource checker
+	registry.Register("host", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewHostChecker(details)
+	})
+
+	// Register the Nagios plugin compatible exec checker
+	registry.Register("exec", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewExecChecker(s.config.PluginsDir, details)
+	})
+
+	// Register the database checkers
+	registry.Register("postgres", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewPostgresChecker(details)
+	})
+
+	registry.Register("mysql", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewMySQLChecker(details)
+	})
+
+	registry.Register("redis", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewRedisChecker(details)
+	})
+
+	// Register the UDP checker
+	registry.Register("udp", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewUDPChecker(details)
+	})
+
+	// Register the NTP clock offset checker
+	registry.Register("ntp", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewNTPChecker(details)
+	})
+
+	// Register the Prometheus scrape checker
+	registry.Register("prometheus", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewPrometheusChecker(details)
+	})
+
+	// Register the log file checker
+	registry.Register("logwatch", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewLogwatchChecker(details)
+	})
+
+	// Register the file integrity and freshness checker
+	registry.Register("file", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewFileChecker(details)
+	})
+
+	// Register the Docker container checker
+	registry.Register("container", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewContainerChecker(details)
+	})
+
+	// Register the banner checker
+	registry.Register("banner", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewBannerChecker(details)
+	})
+
+	// Register the standard gRPC health checker
+	registry.Register("grpc_health", func(_ context.Context, _, details string) (checker.Checker, error) {
+		return NewGRPCHealthChecker(details)
+	})
+
 	return registry
 }
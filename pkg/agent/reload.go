@@ -0,0 +1,255 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/reload.go
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const configPollInterval = 5 * time.Second
+
+// watchConfig reloads the checker and sweep configurations when a file in the
+// config directory changes or the agent receives SIGHUP. The directory is
+// polled rather than watched with inotify so that Kubernetes ConfigMap
+// updates, which swap a symlink, are noticed as well.
+func (s *Server) watchConfig(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+
+	fingerprint := s.configFingerprint()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.done:
+			return
+		case <-hup:
+			log.Printf("Received SIGHUP, reloading configuration from %s", s.configDir)
+		case <-ticker.C:
+			current := s.configFingerprint()
+			if current == fingerprint {
+				continue
+			}
+
+			log.Printf("Configuration in %s changed, reloading", s.configDir)
+		}
+
+		fingerprint = s.configFingerprint()
+
+		if err := s.reloadConfigurations(ctx); err != nil {
+			log.Printf("Warning: Failed to reload configuration: %v", err)
+		}
+	}
+}
+
+// configFingerprint summarizes the name, size and modification time of every
+// config file, following symlinks.
+func (s *Server) configFingerprint() string {
+	files, err := os.ReadDir(s.configDir)
+	if err != nil {
+		return ""
+	}
+
+	paths := make([]string, 0, len(files)+1)
+
+	for _, file := range files {
+		if filepath.Ext(file.Name()) == jsonSuffix {
+			paths = append(paths, filepath.Join(s.configDir, file.Name()))
+		}
+	}
+
+	paths = append(paths, s.sweepConfigPath())
+
+	var b strings.Builder
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		fmt.Fprintf(&b, "%s %d %d\n", path, info.Size(), info.ModTime().UnixNano())
+	}
+
+	return b.String()
+}
+
+// reloadConfigurations applies the current checker and sweep configurations
+// without restarting the agent.
+func (s *Server) reloadConfigurations(ctx context.Context) error {
+	return errors.Join(s.reloadCheckers(ctx), s.reloadSweepService(ctx))
+}
+
+// reloadCheckers replaces the checker configs. Checkers whose config was
+// removed or changed are closed together with their connections and are
+// recreated on the next request. If any file cannot be loaded, for example
+// because it is still being written, the current configs are kept.
+func (s *Server) reloadCheckers(ctx context.Context) error {
+	confs, err := s.readCheckerConfigs()
+	if err != nil {
+		return fmt.Errorf("keeping the current checker configs: %w", err)
+	}
+
+	s.mu.Lock()
+
+	for name, old := range s.checkerConfs {
+		if conf, ok := confs[name]; ok && reflect.DeepEqual(conf, old) {
+			continue
+		}
+
+		log.Printf("Checker config %s was removed or changed", name)
+
+		s.closeChecker(name)
+	}
+
+	for name := range confs {
+		if _, ok := s.checkerConfs[name]; !ok {
+			log.Printf("Checker config %s was added", name)
+		}
+	}
+
+	s.checkerConfs = confs
+
+	s.mu.Unlock()
+
+	s.initializeCheckers(ctx)
+	s.scheduleChecks(ctx)
+
+	return nil
+}
+
+// closeChecker stops the schedule and closes the connection and the cached
+// checkers for the named checker config. The caller must hold s.mu.
+func (s *Server) closeChecker(name string) {
+	if scheduled, ok := s.scheduled[name]; ok {
+		scheduled.cancel()
+		delete(s.scheduled, name)
+	}
+
+	if conn, ok := s.connections[name]; ok {
+		if err := conn.client.Close(); err != nil {
+			log.Printf("Error closing connection to checker %s: %v", name, err)
+		}
+
+		delete(s.connections, name)
+	}
+
+	for key, check := range s.checkers {
+		// Keys are type:name:details and types never contain a colon.
+		if _, rest, _ := strings.Cut(key, ":"); !strings.HasPrefix(rest, name+":") {
+			continue
+		}
+
+		if closer, ok := check.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				log.Printf("Error closing checker %s: %v", name, err)
+			}
+		}
+
+		delete(s.checkers, key)
+	}
+}
+
+// reloadSweepService updates, starts or stops the sweep service to match the
+// sweep config.
+func (s *Server) reloadSweepService(ctx context.Context) error {
+	config, err := loadSweepConfig(s.sweepConfigPath())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("keeping the current sweep config: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := -1
+
+	var sweep *SweepService
+
+	for i, svc := range s.services {
+		if svc, ok := svc.(*SweepService); ok {
+			index, sweep = i, svc
+
+			break
+		}
+	}
+
+	switch {
+	case config == nil && index < 0:
+		return nil
+	case config == nil:
+		log.Printf("Sweep config was removed, stopping the sweep service")
+
+		s.services = append(s.services[:index:index], s.services[index+1:]...)
+
+		return sweep.Stop(context.Background())
+	case index < 0:
+		service, err := s.newSweepService(config)
+		if err != nil {
+			return fmt.Errorf("failed to create sweep service: %w", err)
+		}
+
+		log.Printf("Sweep config was added, starting the sweep service")
+
+		s.services = append(s.services, service)
+
+		go func() {
+			if err := service.Start(ctx); err != nil {
+				log.Printf("Failed to start service %s: %v", service.Name(), err)
+			}
+		}()
+
+		return nil
+	}
+
+	config = applyDefaultConfig(config)
+
+	sweep.mu.RLock()
+	unchanged := reflect.DeepEqual(sweep.config, config)
+	sweep.mu.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	return sweep.UpdateConfig(config)
+}
+
+// runningServices returns a snapshot of the agent's services.
+func (s *Server) runningServices() []Service {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]Service(nil), s.services...)
+}
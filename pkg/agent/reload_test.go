@@ -0,0 +1,200 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/carverauto/serviceradar/pkg/models"
+	"github.com/carverauto/serviceradar/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, path, data string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+}
+
+func TestReloadCheckers(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	writeConfigFile(t, filepath.Join(dir, "web.json"), `{"name": "web", "type": "port", "details": "127.0.0.1:80"}`)
+	writeConfigFile(t, filepath.Join(dir, "dusk.json"), `{"name": "dusk", "type": "grpc", "address": "127.0.0.1:50052"}`)
+
+	s, err := NewServer(dir, &ServerConfig{})
+	require.NoError(t, err)
+
+	s.initializeCheckers(ctx)
+	require.Contains(t, s.connections, "dusk")
+
+	dusk := s.connections["dusk"]
+	req := &proto.StatusRequest{ServiceName: "web", ServiceType: "port"}
+
+	web, err := s.getChecker(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, &PortChecker{Host: "127.0.0.1", Port: 80}, web)
+
+	// An unrelated change keeps the cached checker and the connection.
+	writeConfigFile(t, filepath.Join(dir, "db.json"), `{"name": "db", "type": "port", "details": "127.0.0.1:5432"}`)
+	require.NoError(t, s.reloadConfigurations(ctx))
+
+	cached, err := s.getChecker(ctx, req)
+	require.NoError(t, err)
+	assert.Same(t, web, cached)
+	assert.Same(t, dusk, s.connections["dusk"])
+	assert.ElementsMatch(t, []string{"web", "dusk", "db"}, s.ListServices())
+
+	// Changing a config recreates its checker with the new details.
+	writeConfigFile(t, filepath.Join(dir, "web.json"), `{"name": "web", "type": "port", "details": "127.0.0.1:8080"}`)
+	require.NoError(t, s.reloadConfigurations(ctx))
+
+	cached, err = s.getChecker(ctx, req)
+	require.NoError(t, err)
+	assert.NotSame(t, web, cached)
+	assert.Equal(t, &PortChecker{Host: "127.0.0.1", Port: 8080}, cached)
+
+	// Removing a config closes its connection.
+	require.NoError(t, os.Remove(filepath.Join(dir, "dusk.json")))
+	require.NoError(t, s.reloadConfigurations(ctx))
+
+	assert.NotContains(t, s.connections, "dusk")
+	assert.ElementsMatch(t, []string{"web", "db"}, s.ListServices())
+
+	// A file that cannot be parsed leaves the current configs in place.
+	writeConfigFile(t, filepath.Join(dir, "web.json"), `{"name": "web",`)
+	require.Error(t, s.reloadConfigurations(ctx))
+	assert.ElementsMatch(t, []string{"web", "db"}, s.ListServices())
+}
+
+// fakeSweeper stands in for the network sweeper so no real scan runs.
+type fakeSweeper struct {
+	mu      sync.Mutex
+	config  *models.Config
+	stopped chan struct{}
+}
+
+func (f *fakeSweeper) Start(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-f.stopped:
+		return nil
+	}
+}
+
+func (f *fakeSweeper) Stop(context.Context) error {
+	close(f.stopped)
+
+	return nil
+}
+
+func (*fakeSweeper) GetResults(context.Context, *models.ResultFilter) ([]models.Result, error) {
+	return nil, nil
+}
+
+func (f *fakeSweeper) GetConfig() models.Config {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return *f.config
+}
+
+func (f *fakeSweeper) UpdateConfig(config *models.Config) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.config = config
+
+	return nil
+}
+
+func TestReloadSweepService(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	s, err := NewServer(dir, &ServerConfig{})
+	require.NoError(t, err)
+	require.Empty(t, s.runningServices())
+
+	var fake *fakeSweeper
+
+	s.newSweepService = func(config *models.Config) (Service, error) {
+		config = applyDefaultConfig(config)
+		fake = &fakeSweeper{config: config, stopped: make(chan struct{})}
+
+		return wrapSweeper(config, fake), nil
+	}
+
+	t.Cleanup(func() {
+		for _, svc := range s.runningServices() {
+			_ = svc.Stop(context.Background())
+		}
+	})
+
+	sweepPath := filepath.Join(dir, "sweep", "sweep.json")
+
+	writeConfigFile(t, sweepPath, `{"networks": ["127.0.0.1/32"], "ports": [22], "sweep_modes": ["tcp"], "interval": "1h"}`)
+	require.NoError(t, s.reloadConfigurations(ctx))
+	require.Len(t, s.runningServices(), 1)
+
+	sweep := s.runningServices()[0].(*SweepService)
+
+	writeConfigFile(t, sweepPath, `{"networks": ["127.0.0.1/32"], "ports": [22, 443], "sweep_modes": ["tcp"], "interval": "1h"}`)
+	require.NoError(t, s.reloadConfigurations(ctx))
+
+	sweep.mu.RLock()
+	assert.Equal(t, []int{22, 443}, sweep.config.Ports)
+	assert.Equal(t, time.Hour, sweep.config.Interval)
+	sweep.mu.RUnlock()
+
+	assert.Equal(t, []int{22, 443}, fake.GetConfig().Ports, "the sweeper gets the new config")
+
+	require.NoError(t, os.Remove(sweepPath))
+	require.NoError(t, s.reloadConfigurations(ctx))
+	assert.Empty(t, s.runningServices())
+
+	select {
+	case <-fake.stopped:
+	default:
+		t.Error("the sweeper was not stopped")
+	}
+}
+
+func TestConfigFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	s := &Server{configDir: dir}
+
+	writeConfigFile(t, filepath.Join(dir, "web.json"), `{"name": "web", "type": "port"}`)
+	writeConfigFile(t, filepath.Join(dir, "README.md"), "notes")
+
+	before := s.configFingerprint()
+
+	writeConfigFile(t, filepath.Join(dir, "README.md"), "more notes")
+	assert.Equal(t, before, s.configFingerprint())
+
+	writeConfigFile(t, filepath.Join(dir, "sweep", "sweep.json"), `{"networks": []}`)
+	assert.NotEqual(t, before, s.configFingerprint())
+}
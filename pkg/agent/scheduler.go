@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/scheduler.go
+package agent
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/carverauto/serviceradar/proto"
+)
+
+const minCheckInterval = time.Second
+
+// scheduledCheck runs a checker config on its own interval and keeps the
+// latest result.
+type scheduledCheck struct {
+	conf     CheckerConfig
+	interval time.Duration
+	cancel   context.CancelFunc
+	mu       sync.RWMutex
+	latest   *proto.StatusResponse
+	latestAt time.Time
+}
+
+// maxAge is how old the latest result may get before it is no longer served:
+// two intervals plus the check timeout, so a single late run is tolerated.
+func (sc *scheduledCheck) maxAge() time.Duration {
+	timeout := time.Duration(sc.conf.Timeout)
+	if timeout <= 0 {
+		timeout = defaultCheckTimeout
+	}
+
+	return 2*sc.interval + timeout //nolint:mnd // two intervals
+}
+
+// scheduleChecks starts a schedule for every checker config with an interval
+// that is not scheduled yet.
+func (s *Server) scheduleChecks(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, conf := range s.checkerConfs {
+		if conf.Interval <= 0 {
+			continue
+		}
+
+		if _, ok := s.scheduled[name]; ok {
+			continue
+		}
+
+		interval := max(time.Duration(conf.Interval), minCheckInterval)
+
+		scheduleCtx, cancel := context.WithCancel(ctx)
+		scheduled := &scheduledCheck{conf: conf, interval: interval, cancel: cancel}
+		s.scheduled[name] = scheduled
+
+		log.Printf("Scheduling checker %s (type: %s) every %v", conf.Name, conf.Type, interval)
+
+		go s.runSchedule(scheduleCtx, scheduled, interval)
+	}
+}
+
+// runSchedule runs the check right away and then on every tick until the
+// schedule is canceled.
+func (s *Server) runSchedule(ctx context.Context, scheduled *scheduledCheck, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	req := &proto.StatusRequest{
+		ServiceName: scheduled.conf.Name,
+		ServiceType: scheduled.conf.Type,
+		Timeout:     int64(scheduled.conf.Timeout),
+	}
+
+	for {
+		resp := runWithTimeout(ctx, req, s.executeCheck)
+		if ctx.Err() != nil {
+			return
+		}
+
+		now := time.Now()
+		resp.Timestamp = now.Unix()
+
+		scheduled.mu.Lock()
+		scheduled.latest = resp
+		scheduled.latestAt = now
+		scheduled.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// latestResult returns the latest result of the scheduled check that serves
+// the request, or nil when the check is not scheduled, has not run yet or its
+// result is stale. Requests with details other than the configured ones are
+// not served.
+func (s *Server) latestResult(req *proto.StatusRequest) *proto.StatusResponse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	scheduled, ok := s.scheduled[req.GetServiceName()]
+	if !ok || scheduled.conf.Type != req.GetServiceType() {
+		return nil
+	}
+
+	if req.GetDetails() != "" && req.GetDetails() != s.localCheckerDetails(req) {
+		return nil
+	}
+
+	scheduled.mu.RLock()
+	defer scheduled.mu.RUnlock()
+
+	if time.Since(scheduled.latestAt) > scheduled.maxAge() {
+		return nil
+	}
+
+	return scheduled.latest
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/carverauto/serviceradar/pkg/checker"
+	"github.com/carverauto/serviceradar/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingChecker reports how many times it ran.
+type countingChecker struct {
+	runs *atomic.Int32
+}
+
+func (c *countingChecker) Check(context.Context) (isAvailable bool, statusMsg string) {
+	return true, fmt.Sprintf(`{"runs": %d}`, c.runs.Add(1))
+}
+
+func TestScheduledChecks(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "fast.json"), `{"name": "fast", "type": "counting", "details": "a", "interval": "1h"}`)
+	writeConfigFile(t, filepath.Join(dir, "slow.json"), `{"name": "slow", "type": "counting", "details": "b"}`)
+
+	s, err := NewServer(dir, &ServerConfig{})
+	require.NoError(t, err)
+
+	var runs atomic.Int32
+
+	s.registry.Register("counting", func(context.Context, string, string) (checker.Checker, error) {
+		return &countingChecker{runs: &runs}, nil
+	})
+
+	ctx := context.Background()
+	s.scheduleChecks(ctx)
+
+	t.Cleanup(func() { require.NoError(t, s.Stop(ctx)) })
+
+	require.Contains(t, s.scheduled, "fast")
+	require.NotContains(t, s.scheduled, "slow")
+
+	fast := &proto.StatusRequest{ServiceName: "fast", ServiceType: "counting"}
+
+	require.Eventually(t, func() bool { return s.latestResult(fast) != nil }, time.Second, 10*time.Millisecond)
+
+	// The scheduled result is served without running the check again.
+	for range 3 {
+		resp, err := s.GetStatus(ctx, fast)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"runs": 1}`, resp.Message)
+		assert.InDelta(t, time.Now().Unix(), resp.Timestamp, 5)
+	}
+
+	// So are batch requests and requests repeating the configured details.
+	batch, err := s.GetStatuses(ctx, &proto.StatusesRequest{Requests: []*proto.StatusRequest{
+		{ServiceName: "fast", ServiceType: "counting", Details: "a"},
+	}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"runs": 1}`, batch.Responses[0].Message)
+
+	// Other details and unscheduled checks run on demand.
+	resp, err := s.GetStatus(ctx, &proto.StatusRequest{ServiceName: "fast", ServiceType: "counting", Details: "c"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"runs": 2}`, resp.Message)
+	assert.Zero(t, resp.Timestamp)
+
+	resp, err = s.GetStatus(ctx, &proto.StatusRequest{ServiceName: "slow", ServiceType: "counting"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"runs": 3}`, resp.Message)
+
+	// A result the schedule failed to refresh in time is not served.
+	scheduled := s.scheduled["fast"]
+	scheduled.mu.Lock()
+	scheduled.latestAt = time.Now().Add(-scheduled.maxAge() - time.Second)
+	scheduled.mu.Unlock()
+
+	resp, err = s.GetStatus(ctx, fast)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"runs": 4}`, resp.Message)
+	assert.Zero(t, resp.Timestamp)
+}
+
+func TestScheduledChecksFollowReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fast.json")
+	writeConfigFile(t, path, `{"name": "fast", "type": "port", "details": "127.0.0.1:1", "interval": "1h"}`)
+
+	s, err := NewServer(dir, &ServerConfig{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	s.scheduleChecks(ctx)
+
+	t.Cleanup(func() { require.NoError(t, s.Stop(ctx)) })
+
+	first := s.scheduled["fast"]
+	require.NotNil(t, first)
+
+	writeConfigFile(t, path, `{"name": "fast", "type": "port", "details": "127.0.0.1:2", "interval": "1h"}`)
+	require.NoError(t, s.reloadConfigurations(ctx))
+
+	s.mu.RLock()
+	second := s.scheduled["fast"]
+	s.mu.RUnlock()
+
+	require.NotNil(t, second)
+	assert.NotSame(t, first, second)
+
+	writeConfigFile(t, path, `{"name": "fast", "type": "port", "details": "127.0.0.1:2"}`)
+	require.NoError(t, s.reloadConfigurations(ctx))
+
+	s.mu.RLock()
+	assert.NotContains(t, s.scheduled, "fast")
+	s.mu.RUnlock()
+}
+
+func TestScheduledExternalChecks(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "snmp.json"),
+		`{"name": "snmp", "node_address": "localhost:50051", "listen_addr": ":50054", "interval": "1h", "timeout": "20s"}`)
+	writeConfigFile(t, filepath.Join(dir, "dusk.json"),
+		`{"name": "dusk", "type": "grpc", "address": "127.0.0.1:50052", "interval": "1h"}`)
+
+	s, err := NewServer(dir, &ServerConfig{})
+	require.NoError(t, err)
+
+	snmpConf := s.checkerConfs["snmp"]
+	assert.Equal(t, "localhost:50054", snmpConf.Address)
+	assert.Equal(t, Duration(time.Hour), snmpConf.Interval)
+	assert.Equal(t, Duration(20*time.Second), snmpConf.Timeout)
+
+	// The schedule sends no details, so the checkers get the configured address.
+	addresses := make(chan string, 2)
+	fake := func(_ context.Context, _, details string) (checker.Checker, error) {
+		addresses <- details
+
+		return &countingChecker{runs: &atomic.Int32{}}, nil
+	}
+
+	s.registry.Register("snmp", fake)
+	s.registry.Register("grpc", fake)
+
+	ctx := context.Background()
+	s.scheduleChecks(ctx)
+
+	t.Cleanup(func() { require.NoError(t, s.Stop(ctx)) })
+
+	requests := []*proto.StatusRequest{
+		{ServiceName: "snmp", ServiceType: "snmp", Details: "localhost:50054"},
+		{ServiceName: "dusk", ServiceType: "grpc"},
+	}
+
+	for _, req := range requests {
+		require.Eventually(t, func() bool { return s.latestResult(req) != nil }, time.Second, 10*time.Millisecond)
+		assert.True(t, s.latestResult(req).Available)
+	}
+
+	assert.ElementsMatch(t, []string{"localhost:50054", "127.0.0.1:50052"}, []string{<-addresses, <-addresses})
+}
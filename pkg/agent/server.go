@@ -21,10 +21,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/carverauto/serviceradar/pkg/checker"
@@ -39,22 +42,29 @@ const (
 	snmpPrefix         = "snmp"
 	grpcType           = "grpc"
 	defaultErrChansize = 10
+
+	// defaultCheckTimeout bounds each check of a GetStatuses batch that does
+	// not set its own timeout.
+	defaultCheckTimeout = 10 * time.Second
 )
 
 func NewServer(configDir string, cfg *ServerConfig) (*Server, error) {
 	s := &Server{
-		checkers:     make(map[string]checker.Checker),
-		checkerConfs: make(map[string]CheckerConfig),
-		configDir:    configDir,
-		services:     make([]Service, 0),
-		listenAddr:   cfg.ListenAddr,
-		registry:     initRegistry(),
-		errChan:      make(chan error, defaultErrChansize),
-		done:         make(chan struct{}),
-		config:       cfg,
-		connections:  make(map[string]*CheckerConnection),
+		checkers:        make(map[string]checker.Checker),
+		checkerConfs:    make(map[string]CheckerConfig),
+		configDir:       configDir,
+		services:        make([]Service, 0),
+		listenAddr:      cfg.ListenAddr,
+		errChan:         make(chan error, defaultErrChansize),
+		done:            make(chan struct{}),
+		config:          cfg,
+		connections:     make(map[string]*CheckerConnection),
+		scheduled:       make(map[string]*scheduledCheck),
+		newSweepService: NewSweepService,
 	}
 
+	s.registry = initRegistry(s)
+
 	if err := s.loadConfigurations(); err != nil {
 		return nil, fmt.Errorf("failed to load configurations: %w", err)
 	}
@@ -67,8 +77,7 @@ func (s *Server) loadConfigurations() error {
 		return fmt.Errorf("failed to load checker configs: %w", err)
 	}
 
-	sweepConfigPath := filepath.Join(s.configDir, "sweep", "sweep.json")
-	service, err := s.loadSweepService(sweepConfigPath)
+	service, err := s.loadSweepService(s.sweepConfigPath())
 
 	if err != nil && !errors.Is(err, os.ErrNotExist) {
 		return fmt.Errorf("failed to load sweep service: %w", err)
@@ -81,7 +90,11 @@ func (s *Server) loadConfigurations() error {
 	return nil
 }
 
-func (*Server) loadSweepService(configPath string) (Service, error) {
+func (s *Server) sweepConfigPath() string {
+	return filepath.Join(s.configDir, "sweep", "sweep.json")
+}
+
+func loadSweepConfig(configPath string) (*models.Config, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		return nil, err
@@ -93,16 +106,23 @@ func (*Server) loadSweepService(configPath string) (Service, error) {
 		return nil, fmt.Errorf("failed to parse sweep config: %w", err)
 	}
 
-	config := &models.Config{
+	return &models.Config{
 		Networks:    sweepConfig.Networks,
 		Ports:       sweepConfig.Ports,
 		SweepModes:  sweepConfig.SweepModes,
 		Interval:    time.Duration(sweepConfig.Interval),
 		Concurrency: sweepConfig.Concurrency,
 		Timeout:     time.Duration(sweepConfig.Timeout),
+	}, nil
+}
+
+func (s *Server) loadSweepService(configPath string) (Service, error) {
+	config, err := loadSweepConfig(configPath)
+	if err != nil {
+		return nil, err
 	}
 
-	service, err := NewSweepService(config)
+	service, err := s.newSweepService(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create sweep service: %w", err)
 	}
@@ -115,9 +135,8 @@ func (*Server) loadSweepService(configPath string) (Service, error) {
 func (s *Server) Start(ctx context.Context) error {
 	log.Printf("Starting agent service...")
 
-	if err := s.initializeCheckers(ctx); err != nil {
-		return fmt.Errorf("failed to initialize checkers: %w", err)
-	}
+	s.initializeCheckers(ctx)
+	s.scheduleChecks(ctx)
 
 	log.Printf("Found %d services to start", len(s.services))
 
@@ -133,24 +152,41 @@ func (s *Server) Start(ctx context.Context) error {
 		}(svc)
 	}
 
+	go s.watchConfig(ctx)
+
 	return nil
 }
 
 func (s *Server) Stop(_ context.Context) error {
 	log.Printf("Stopping agent service...")
 
-	for _, svc := range s.services {
+	for _, svc := range s.runningServices() {
 		if err := svc.Stop(context.Background()); err != nil {
 			log.Printf("Failed to stop service %s: %v", svc.Name(), err)
 		}
 	}
 
+	s.mu.Lock()
+	for name, scheduled := range s.scheduled {
+		scheduled.cancel()
+		delete(s.scheduled, name)
+	}
+
 	for name, conn := range s.connections {
 		if err := conn.client.Close(); err != nil {
 			log.Printf("Error closing connection to checker %s: %v", name, err)
 		}
 	}
 
+	for _, check := range s.checkers {
+		if closer, ok := check.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				log.Printf("Error closing checker: %v", err)
+			}
+		}
+	}
+	s.mu.Unlock()
+
 	close(s.done)
 
 	return nil
@@ -177,11 +213,7 @@ func (*Server) loadCheckerConfig(path string) (CheckerConfig, error) {
 	}
 
 	if strings.HasPrefix(filepath.Base(path), snmpPrefix) {
-		conf.Name = "snmp-" + strings.TrimSuffix(filepath.Base(path), jsonSuffix)
-		conf.Type = snmpPrefix
-		conf.Additional = data
-
-		return conf, nil
+		return loadSNMPCheckerConfig(path, data)
 	}
 
 	if err := json.Unmarshal(data, &conf); err != nil {
@@ -196,48 +228,91 @@ func (*Server) loadCheckerConfig(path string) (CheckerConfig, error) {
 		conf.Address = conf.ListenAddr
 	}
 
-	log.Printf("Loaded checker config from %s: %+v", path, conf)
+	log.Printf("Loaded checker config from %s: %s (type: %s)", path, conf.Name, conf.Type)
 
 	return conf, nil
 }
 
-func (s *Server) initializeCheckers(ctx context.Context) error {
-	files, err := os.ReadDir(s.configDir)
+// snmpCheckerConfig is the part of an SNMP checker config the agent uses to
+// name, reach and schedule the SNMP checker.
+type snmpCheckerConfig struct {
+	Name       string   `json:"name"`
+	ListenAddr string   `json:"listen_addr"`
+	Interval   Duration `json:"interval"`
+	Timeout    Duration `json:"timeout"`
+}
+
+func loadSNMPCheckerConfig(path string, data []byte) (CheckerConfig, error) {
+	var snmpConf snmpCheckerConfig
+
+	if err := json.Unmarshal(data, &snmpConf); err != nil {
+		return CheckerConfig{}, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	conf := CheckerConfig{
+		Name:       snmpConf.Name,
+		Type:       snmpPrefix,
+		Address:    localAddress(snmpConf.ListenAddr),
+		Timeout:    snmpConf.Timeout,
+		Interval:   snmpConf.Interval,
+		Additional: data,
+	}
+
+	if conf.Name == "" {
+		conf.Name = "snmp-" + strings.TrimSuffix(filepath.Base(path), jsonSuffix)
+	}
+
+	if conf.Timeout == 0 {
+		conf.Timeout = Duration(defaultTimeout)
+	}
+
+	log.Printf("Loaded SNMP checker config from %s: %s", path, conf.Name)
+
+	return conf, nil
+}
+
+// localAddress turns a listen address such as ":50054" into an address the
+// agent can connect to on the same host.
+func localAddress(listenAddr string) string {
+	host, port, err := net.SplitHostPort(listenAddr)
 	if err != nil {
-		return fmt.Errorf("failed to read config directory: %w", err)
+		return listenAddr
 	}
 
-	s.connections = make(map[string]*CheckerConnection)
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
 
-	for _, file := range files {
-		if filepath.Ext(file.Name()) != jsonSuffix {
-			continue
-		}
+	return net.JoinHostPort(host, port)
+}
 
-		config, err := s.loadCheckerConfig(filepath.Join(s.configDir, file.Name()))
-		if err != nil {
-			log.Printf("Warning: Failed to load checker config %s: %v", file.Name(), err)
+// initializeCheckers connects to the external checkers that do not have a
+// connection yet.
+func (s *Server) initializeCheckers(ctx context.Context) {
+	s.mu.RLock()
 
-			continue
+	pending := make([]CheckerConfig, 0, len(s.checkerConfs))
+
+	for name, conf := range s.checkerConfs {
+		if _, connected := s.connections[name]; conf.Type == grpcType && !connected {
+			pending = append(pending, conf)
 		}
+	}
 
-		if config.Type == grpcType {
-			conn, err := s.connectToChecker(ctx, &config)
-			if err != nil {
-				log.Printf("Warning: Failed to connect to checker %s: %v", config.Name, err)
+	s.mu.RUnlock()
 
-				continue
-			}
+	for i := range pending {
+		conn, err := s.connectToChecker(ctx, &pending[i])
+		if err != nil {
+			log.Printf("Warning: Failed to connect to checker %s: %v", pending[i].Name, err)
 
-			s.connections[config.Name] = conn
+			continue
 		}
 
-		s.checkerConfs[config.Name] = config
-
-		log.Printf("Loaded checker config: %s (type: %s)", config.Name, config.Type)
+		s.mu.Lock()
+		s.connections[pending[i].Name] = conn
+		s.mu.Unlock()
 	}
-
-	return nil
 }
 
 func (s *Server) connectToChecker(ctx context.Context, checkerConfig *CheckerConfig) (*CheckerConnection, error) {
@@ -246,8 +321,8 @@ func (s *Server) connectToChecker(ctx context.Context, checkerConfig *CheckerCon
 		MaxRetries: 3,
 	}
 
-	if s.config.Security != nil {
-		provider, err := grpc.NewSecurityProvider(ctx, s.config.Security)
+	if security := checkerSecurity(checkerConfig, s.config.Security, checkerConfig.Address); security != nil {
+		provider, err := grpc.NewSecurityProvider(ctx, security)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create security provider: %w", err)
 		}
@@ -275,11 +350,23 @@ var (
 	errICMPCheck      = errors.New("ICMP check failed")
 )
 
+// GetStatus returns the latest result of a check scheduled by the agent, or
+// runs the check when it is not scheduled.
 func (s *Server) GetStatus(ctx context.Context, req *proto.StatusRequest) (*proto.StatusResponse, error) {
-	log.Printf("Received status request: %+v", req)
+	// Details are not logged; they can hold credentials.
+	log.Printf("Received status request for %s (type: %s)", req.GetServiceName(), req.GetServiceType())
+
+	if resp := s.latestResult(req); resp != nil {
+		return resp, nil
+	}
 
+	return s.executeCheck(ctx, req)
+}
+
+// executeCheck runs the requested check.
+func (s *Server) executeCheck(ctx context.Context, req *proto.StatusRequest) (*proto.StatusResponse, error) {
 	if req.ServiceType == "icmp" && req.Details != "" {
-		for _, svc := range s.services {
+		for _, svc := range s.runningServices() {
 			sweepSvc, ok := svc.(*SweepService)
 			if !ok {
 				continue // Skip if svc is not a SweepService
@@ -330,57 +417,127 @@ func (s *Server) GetStatus(ctx context.Context, req *proto.StatusRequest) (*prot
 	}, nil
 }
 
-func (s *Server) loadCheckerConfigs() error {
-	files, err := os.ReadDir(s.configDir)
-	if err != nil {
-		return fmt.Errorf("failed to read config directory: %w", err)
+// GetStatuses runs a batch of status requests concurrently, each bounded by
+// its own timeout, and returns the results in request order. A failed check
+// is reported as unavailable rather than failing the whole batch.
+func (s *Server) GetStatuses(ctx context.Context, req *proto.StatusesRequest) (*proto.StatusesResponse, error) {
+	log.Printf("Received batch status request with %d checks", len(req.GetRequests()))
+
+	responses := make([]*proto.StatusResponse, len(req.GetRequests()))
+
+	var wg sync.WaitGroup
+
+	for i, statusReq := range req.GetRequests() {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			responses[i] = runWithTimeout(ctx, statusReq, s.GetStatus)
+		}()
 	}
 
-	for _, file := range files {
-		if filepath.Ext(file.Name()) != ".json" {
-			continue
-		}
+	wg.Wait()
 
-		path := filepath.Join(s.configDir, file.Name())
+	return &proto.StatusesResponse{Responses: responses}, nil
+}
 
-		data, err := os.ReadFile(path)
-		if err != nil {
-			log.Printf("Warning: Failed to read config file %s: %v", path, err)
+// runWithTimeout runs a single request with the request's timeout and reports
+// failures as unavailable. A check that ignores its context is abandoned once
+// the timeout has passed.
+func runWithTimeout(
+	ctx context.Context,
+	req *proto.StatusRequest,
+	run func(context.Context, *proto.StatusRequest) (*proto.StatusResponse, error)) *proto.StatusResponse {
+	timeout := time.Duration(req.GetTimeout())
+	if timeout <= 0 {
+		timeout = defaultCheckTimeout
+	}
 
-			continue
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		resp *proto.StatusResponse
+		err  error
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+		resp, err := run(ctx, req)
+		done <- result{resp: resp, err: err}
+	}()
+
+	var err error
+
+	select {
+	case r := <-done:
+		if r.err == nil {
+			return r.resp
 		}
 
-		if strings.HasPrefix(file.Name(), "snmp") {
-			var conf CheckerConfig
+		err = r.err
+	case <-ctx.Done():
+		err = fmt.Errorf("%w after %v", errCheckTimeout, timeout)
+	}
 
-			conf.Name = "snmp-" + strings.TrimSuffix(file.Name(), ".json")
-			conf.Type = "snmp"
-			conf.Additional = data
+	return &proto.StatusResponse{
+		Available:   false,
+		Message:     err.Error(),
+		ServiceName: req.GetServiceName(),
+		ServiceType: req.GetServiceType(),
+	}
+}
 
-			s.checkerConfs[conf.Name] = conf
+func (s *Server) loadCheckerConfigs() error {
+	confs, err := s.readCheckerConfigs()
+	if confs == nil {
+		return err
+	}
+
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
 
-			log.Printf("Loaded SNMP checker config: %s", conf.Name)
+	s.checkerConfs = confs
 
+	return nil
+}
+
+// readCheckerConfigs loads every checker config in the config directory. The
+// returned error joins the errors of the files that could not be loaded; the
+// map is nil only when the directory itself cannot be read.
+func (s *Server) readCheckerConfigs() (map[string]CheckerConfig, error) {
+	files, err := os.ReadDir(s.configDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config directory: %w", err)
+	}
+
+	confs := make(map[string]CheckerConfig)
+
+	var errs []error
+
+	for _, file := range files {
+		if filepath.Ext(file.Name()) != jsonSuffix {
 			continue
 		}
 
-		var conf CheckerConfig
-		if err := json.Unmarshal(data, &conf); err != nil {
-			log.Printf("Warning: Failed to parse config file %s: %v", path, err)
+		conf, err := s.loadCheckerConfig(filepath.Join(s.configDir, file.Name()))
+		if err != nil {
+			errs = append(errs, err)
 
 			continue
 		}
 
-		s.checkerConfs[conf.Name] = conf
-
-		log.Printf("Loaded checker config: %s (type: %s)", conf.Name, conf.Type)
+		confs[conf.Name] = conf
 	}
 
-	return nil
+	return confs, errors.Join(errs...)
 }
 
 func (s *Server) getSweepStatus(ctx context.Context) (*proto.StatusResponse, error) {
-	for _, svc := range s.services {
+	for _, svc := range s.runningServices() {
 		if provider, ok := svc.(SweepStatusProvider); ok {
 			return provider.GetStatus(ctx)
 		}
@@ -398,8 +555,7 @@ func (s *Server) getChecker(ctx context.Context, req *proto.StatusRequest) (chec
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	log.Printf("Getting checker for request - Type: %s, Name: %s, Details: %s",
-		req.GetServiceType(), req.GetServiceName(), req.GetDetails())
+	log.Printf("Getting checker for request - Type: %s, Name: %s", req.GetServiceType(), req.GetServiceName())
 
 	key := fmt.Sprintf("%s:%s:%s", req.GetServiceType(), req.GetServiceName(), req.GetDetails())
 	if check, exists := s.checkers[key]; exists {
@@ -407,7 +563,11 @@ func (s *Server) getChecker(ctx context.Context, req *proto.StatusRequest) (chec
 	}
 
 	details := req.GetDetails()
-	log.Printf("Creating new checker with details: %s", details)
+	if details == "" {
+		details = s.localCheckerDetails(req)
+	}
+
+	log.Printf("Creating new checker for %s", req.GetServiceName())
 
 	check, err := s.registry.Get(ctx, req.ServiceType, req.ServiceName, details)
 	if err != nil {
@@ -419,6 +579,44 @@ func (s *Server) getChecker(ctx context.Context, req *proto.StatusRequest) (chec
 	return check, nil
 }
 
+// externalCheckerSecurity returns the security settings for the external
+// checker with the given name. It is called from getChecker with s.mu held.
+func (s *Server) externalCheckerSecurity(serviceName, address string) *models.SecurityConfig {
+	var agentSecurity *models.SecurityConfig
+	if s.config != nil {
+		agentSecurity = s.config.Security
+	}
+
+	if conf, ok := s.checkerConfs[serviceName]; ok {
+		return checkerSecurity(&conf, agentSecurity, address)
+	}
+
+	return checkerSecurity(nil, agentSecurity, address)
+}
+
+// localCheckerDetails returns the details from the agent's own checker config
+// with the requested name and type. This keeps secrets such as database
+// credentials on the agent instead of in the poller configuration. External
+// (grpc and snmp) checkers without details are reached at their address.
+func (s *Server) localCheckerDetails(req *proto.StatusRequest) string {
+	conf, ok := s.checkerConfs[req.GetServiceName()]
+	if !ok || conf.Type != req.GetServiceType() {
+		return ""
+	}
+
+	if len(conf.Details) == 0 {
+		return conf.Address
+	}
+
+	// Details may be a JSON string ("host:port") or an object.
+	var details string
+	if err := json.Unmarshal(conf.Details, &details); err == nil {
+		return details
+	}
+
+	return string(conf.Details)
+}
+
 func (s *Server) ListServices() []string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
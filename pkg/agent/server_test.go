@@ -187,6 +187,53 @@ func TestServerGetStatus(t *testing.T) {
 	}
 }
 
+// blockingChecker ignores its context and only returns once released.
+type blockingChecker struct {
+	release chan struct{}
+}
+
+func (c *blockingChecker) Check(context.Context) (isAvailable bool, statusMsg string) {
+	<-c.release
+
+	return true, "released"
+}
+
+func TestServerGetStatuses(t *testing.T) {
+	server, err := NewServer(t.TempDir(), &ServerConfig{})
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	server.registry.Register("blocking", func(context.Context, string, string) (checker.Checker, error) {
+		return &blockingChecker{release: release}, nil
+	})
+
+	resp, err := server.GetStatuses(context.Background(), &proto.StatusesRequest{
+		Requests: []*proto.StatusRequest{
+			{ServiceType: "sweep", ServiceName: "network_sweep"},
+			{ServiceType: "unknown", ServiceName: "mystery"},
+			{ServiceType: "blocking", ServiceName: "stuck", Timeout: int64(50 * time.Millisecond)},
+			{ServiceType: "port", ServiceName: "closed", Details: closedTCPAddress(t)},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Responses, 4)
+
+	assert.Equal(t, "Sweep service not configured", resp.Responses[0].Message)
+
+	assert.Equal(t, "mystery", resp.Responses[1].ServiceName)
+	assert.False(t, resp.Responses[1].Available)
+	assert.Contains(t, resp.Responses[1].Message, "no checker found")
+
+	assert.Equal(t, "stuck", resp.Responses[2].ServiceName)
+	assert.False(t, resp.Responses[2].Available)
+	assert.Contains(t, resp.Responses[2].Message, errCheckTimeout.Error())
+
+	assert.Equal(t, "closed", resp.Responses[3].ServiceName)
+	assert.False(t, resp.Responses[3].Available)
+}
+
 func TestServerLifecycle(t *testing.T) {
 	// Create a temporary directory for config files
 	tmpDir, err := os.MkdirTemp("", "serviceradar-test")
@@ -256,8 +303,9 @@ func TestGetCheckerCaching(t *testing.T) {
 	// and an initialized registry.
 	s := &Server{
 		checkers: make(map[string]checker.Checker),
-		registry: initRegistry(),
+		config:   &ServerConfig{},
 	}
+	s.registry = initRegistry(s)
 
 	ctx := context.Background()
 
@@ -288,3 +336,31 @@ func TestGetCheckerCaching(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEqual(t, checker1a, checker2, "requests with different details should yield different checker instances")
 }
+
+func TestGetCheckerUsesLocalDetails(t *testing.T) {
+	address := startFakeServer(t, (&fakeRedis{role: "master"}).handle)
+
+	s := &Server{
+		checkers: make(map[string]checker.Checker),
+		checkerConfs: map[string]CheckerConfig{
+			"cache": {
+				Name:    "cache",
+				Type:    "redis",
+				Details: json.RawMessage(databaseDetails(address, fakeDBPassword, "")),
+			},
+		},
+		config: &ServerConfig{},
+	}
+	s.registry = initRegistry(s)
+
+	// The poller only names the check; credentials come from the agent config.
+	c, err := s.getChecker(context.Background(), &proto.StatusRequest{ServiceName: "cache", ServiceType: "redis"})
+	require.NoError(t, err)
+
+	ok, msg := c.Check(context.Background())
+	assert.True(t, ok, msg)
+
+	// A config of another type is not used.
+	_, err = s.getChecker(context.Background(), &proto.StatusRequest{ServiceName: "cache", ServiceType: "postgres"})
+	require.ErrorIs(t, err, errDetailsRequiredDatabase)
+}
@@ -50,16 +50,26 @@ func NewSweepService(config *models.Config) (Service, error) {
 		return nil, fmt.Errorf("failed to create network sweeper: %w", err)
 	}
 
+	return wrapSweeper(config, sweeperInstance), nil
+}
+
+// wrapSweeper wraps a sweeper created from config, which must already
+// have the defaults applied.
+func wrapSweeper(config *models.Config, sweeperInstance sweeper.Sweeper) *SweepService {
 	return &SweepService{
 		sweeper: sweeperInstance,
 		config:  config,
 		closed:  make(chan struct{}),
 		stats:   newScanStats(),
-	}, nil
+	}
 }
 
 func (s *SweepService) Start(ctx context.Context) error {
-	log.Printf("Starting sweep service with interval %v", s.config.Interval)
+	s.mu.RLock()
+	interval := s.config.Interval
+	s.mu.RUnlock()
+
+	log.Printf("Starting sweep service with interval %v", interval)
 
 	err := s.sweeper.Start(ctx)
 	if err != nil {
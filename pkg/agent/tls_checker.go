@@ -0,0 +1,415 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/tls_checker.go
+package agent
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTLSTimeout          = 10 * time.Second
+	defaultTLSMinDaysRemaining = 14
+	hoursPerDay                = 24
+	postgresSSLRequestCode     = 80877103
+	postgresSSLRequestLength   = 8
+	startTLSSMTP               = "smtp"
+	startTLSIMAP               = "imap"
+	startTLSPostgres           = "postgres"
+)
+
+// TLSCheckerConfig describes the endpoint a TLSChecker connects to.
+type TLSCheckerConfig struct {
+	Address          string   `json:"address"`
+	ServerName       string   `json:"server_name,omitempty"`        // SNI and the name verified against the certificate
+	StartTLS         string   `json:"starttls,omitempty"`           // smtp, imap or postgres
+	CAFile           string   `json:"ca_file,omitempty"`            // PEM roots used instead of the system pool
+	MinDaysRemaining *int     `json:"min_days_remaining,omitempty"` // default 14, 0 disables the expiry threshold
+	SkipVerify       bool     `json:"skip_verify,omitempty"`        // report chain errors without failing the check
+	Timeout          Duration `json:"timeout,omitempty"`
+}
+
+// TLSChecker connects to a TLS endpoint and validates its certificate chain and expiry.
+type TLSChecker struct {
+	config TLSCheckerConfig
+	roots  *x509.CertPool
+}
+
+// TLSCertificateInfo describes a single certificate presented by the server.
+type TLSCertificateInfo struct {
+	Subject       string    `json:"subject"`
+	Issuer        string    `json:"issuer"`
+	NotBefore     time.Time `json:"not_before"`
+	NotAfter      time.Time `json:"not_after"`
+	DaysRemaining int       `json:"days_remaining"`
+}
+
+// TLSResponse defines the structure of the TLS check result.
+type TLSResponse struct {
+	Address         string               `json:"address"`
+	ServerName      string               `json:"server_name"`
+	Subject         string               `json:"subject,omitempty"`
+	Issuer          string               `json:"issuer,omitempty"`
+	SANs            []string             `json:"sans,omitempty"`
+	NotAfter        time.Time            `json:"not_after,omitempty"`
+	DaysUntilExpiry int                  `json:"days_until_expiry"` // earliest expiry across the presented chain
+	Version         string               `json:"version,omitempty"`
+	CipherSuite     string               `json:"cipher_suite,omitempty"`
+	Chain           []TLSCertificateInfo `json:"chain,omitempty"`
+	Valid           bool                 `json:"valid"`
+	ValidationError string               `json:"validation_error,omitempty"`
+	ResponseTime    int64                `json:"response_time"` // in nanoseconds
+	Available       bool                 `json:"available"`
+	Error           string               `json:"error,omitempty"`
+}
+
+// NewTLSChecker creates a TLSChecker from the check details, which are
+// either "host:port" or a JSON encoded TLSCheckerConfig.
+func NewTLSChecker(details string) (*TLSChecker, error) {
+	if details == "" {
+		return nil, errDetailsRequiredTLS
+	}
+
+	var cfg TLSCheckerConfig
+
+	if strings.HasPrefix(strings.TrimSpace(details), "{") {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse TLS check details: %w", err)
+		}
+	} else {
+		cfg.Address = strings.TrimSpace(details)
+	}
+
+	if err := cfg.applyDefaults(); err != nil {
+		return nil, err
+	}
+
+	c := &TLSChecker{config: cfg}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+
+		c.roots = x509.NewCertPool()
+		if !c.roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %s", errNoCertificates, cfg.CAFile)
+		}
+	}
+
+	return c, nil
+}
+
+func (cfg *TLSCheckerConfig) applyDefaults() error {
+	host, port, err := net.SplitHostPort(cfg.Address)
+	if err != nil {
+		return errInvalidDetailsFormat
+	}
+
+	if p, err := strconv.Atoi(port); err != nil || p <= 0 || p > 65535 {
+		return fmt.Errorf("%w: %s", errInvalidPort, port)
+	}
+
+	switch cfg.StartTLS {
+	case "", startTLSSMTP, startTLSIMAP, startTLSPostgres:
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedStartTLS, cfg.StartTLS)
+	}
+
+	if cfg.ServerName == "" {
+		cfg.ServerName = host
+	}
+
+	if cfg.MinDaysRemaining == nil {
+		days := defaultTLSMinDaysRemaining
+		cfg.MinDaysRemaining = &days
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = Duration(defaultTLSTimeout)
+	}
+
+	return nil
+}
+
+// Check performs the TLS handshake and evaluates the presented certificates.
+func (c *TLSChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout))
+	defer cancel()
+
+	resp := &TLSResponse{
+		Address:    c.config.Address,
+		ServerName: c.config.ServerName,
+	}
+
+	if err := c.inspect(ctx, resp); err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Available = c.evaluate(resp)
+	}
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal TLS response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+func (c *TLSChecker) evaluate(resp *TLSResponse) bool {
+	if resp.DaysUntilExpiry < *c.config.MinDaysRemaining {
+		resp.Error = fmt.Sprintf("%v: %d days remaining (threshold %d)",
+			errCertificateExpiring, resp.DaysUntilExpiry, *c.config.MinDaysRemaining)
+
+		return false
+	}
+
+	if !resp.Valid && !c.config.SkipVerify {
+		resp.Error = resp.ValidationError
+
+		return false
+	}
+
+	return true
+}
+
+func (c *TLSChecker) inspect(ctx context.Context, resp *TLSResponse) error {
+	start := time.Now()
+
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "tcp", c.config.Address)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Printf("Error closing TLS check connection: %v", err)
+		}
+	}()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if err = negotiateStartTLS(conn, c.config.StartTLS); err != nil {
+		return fmt.Errorf("STARTTLS negotiation failed: %w", err)
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName: c.config.ServerName,
+		// The chain is verified below so that certificate details can be
+		// reported even when validation fails.
+		InsecureSkipVerify: true, //nolint:gosec // verified manually in describeChain
+	})
+
+	if err = tlsConn.HandshakeContext(ctx); err != nil {
+		return fmt.Errorf("TLS handshake failed: %w", err)
+	}
+
+	resp.ResponseTime = time.Since(start).Nanoseconds()
+
+	state := tlsConn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return errNoCertificates
+	}
+
+	resp.Version = tls.VersionName(state.Version)
+	resp.CipherSuite = tls.CipherSuiteName(state.CipherSuite)
+
+	c.describeChain(state.PeerCertificates, resp)
+
+	return nil
+}
+
+func (c *TLSChecker) describeChain(certs []*x509.Certificate, resp *TLSResponse) {
+	now := time.Now()
+	leaf := certs[0]
+
+	resp.Subject = leaf.Subject.String()
+	resp.Issuer = leaf.Issuer.String()
+	resp.NotAfter = leaf.NotAfter
+	resp.SANs = append(resp.SANs, leaf.DNSNames...)
+
+	for _, ip := range leaf.IPAddresses {
+		resp.SANs = append(resp.SANs, ip.String())
+	}
+
+	intermediates := x509.NewCertPool()
+
+	for i, cert := range certs {
+		days := int(cert.NotAfter.Sub(now).Hours() / hoursPerDay)
+
+		if i == 0 || days < resp.DaysUntilExpiry {
+			resp.DaysUntilExpiry = days
+		}
+
+		if i > 0 {
+			intermediates.AddCert(cert)
+		}
+
+		resp.Chain = append(resp.Chain, TLSCertificateInfo{
+			Subject:       cert.Subject.String(),
+			Issuer:        cert.Issuer.String(),
+			NotBefore:     cert.NotBefore,
+			NotAfter:      cert.NotAfter,
+			DaysRemaining: days,
+		})
+	}
+
+	_, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       c.config.ServerName,
+		Roots:         c.roots, // nil uses the system roots
+		Intermediates: intermediates,
+		CurrentTime:   now,
+	})
+	if err != nil {
+		resp.ValidationError = err.Error()
+
+		return
+	}
+
+	resp.Valid = true
+}
+
+// negotiateStartTLS upgrades a plaintext connection to the point where a
+// TLS handshake can begin for protocols that require it.
+func negotiateStartTLS(conn net.Conn, protocol string) error {
+	switch protocol {
+	case startTLSSMTP:
+		return startTLSForSMTP(conn)
+	case startTLSIMAP:
+		return startTLSForIMAP(conn)
+	case startTLSPostgres:
+		return startTLSForPostgres(conn)
+	}
+
+	return nil
+}
+
+func startTLSForSMTP(conn net.Conn) error {
+	reader := bufio.NewReader(conn)
+
+	if err := expectSMTPReply(reader, "220"); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(conn, "EHLO serviceradar\r\n"); err != nil {
+		return err
+	}
+
+	if err := expectSMTPReply(reader, "250"); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(conn, "STARTTLS\r\n"); err != nil {
+		return err
+	}
+
+	return expectSMTPReply(reader, "220")
+}
+
+// expectSMTPReply reads a (possibly multi-line) SMTP reply and checks its code.
+func expectSMTPReply(reader *bufio.Reader, code string) error {
+	const minReplyLength = 4
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+
+		if len(line) < minReplyLength || !strings.HasPrefix(line, code) {
+			return fmt.Errorf("%w: %q", errUnexpectedReply, strings.TrimSpace(line))
+		}
+
+		// "250-" continues a multi-line reply, "250 " ends it.
+		if line[3] == ' ' {
+			return nil
+		}
+	}
+}
+
+func startTLSForIMAP(conn net.Conn) error {
+	reader := bufio.NewReader(conn)
+
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(line, "* OK") {
+		return fmt.Errorf("%w: %q", errUnexpectedReply, strings.TrimSpace(line))
+	}
+
+	if _, err = fmt.Fprint(conn, "sr1 STARTTLS\r\n"); err != nil {
+		return err
+	}
+
+	for {
+		line, err = reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(line, "sr1 ") {
+			break
+		}
+	}
+
+	if !strings.HasPrefix(line, "sr1 OK") {
+		return fmt.Errorf("%w: %q", errUnexpectedReply, strings.TrimSpace(line))
+	}
+
+	return nil
+}
+
+func startTLSForPostgres(conn net.Conn) error {
+	req := make([]byte, postgresSSLRequestLength)
+	binary.BigEndian.PutUint32(req[0:4], postgresSSLRequestLength)
+	binary.BigEndian.PutUint32(req[4:8], postgresSSLRequestCode)
+
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	reply := make([]byte, 1)
+	if _, err := conn.Read(reply); err != nil {
+		return err
+	}
+
+	if reply[0] != 'S' {
+		return fmt.Errorf("%w: server does not support SSL", errUnexpectedReply)
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	return path
+}
+
+func TestNewTLSChecker(t *testing.T) {
+	tests := []struct {
+		name        string
+		details     string
+		wantSNI     string
+		wantMinDays int
+		wantError   bool
+	}{
+		{name: "host:port", details: "example.com:443", wantSNI: "example.com", wantMinDays: defaultTLSMinDaysRemaining},
+		{
+			name:        "JSON with SNI",
+			details:     `{"address": "10.0.0.1:993", "server_name": "mail.example.com"}`,
+			wantSNI:     "mail.example.com",
+			wantMinDays: defaultTLSMinDaysRemaining,
+		},
+		{name: "expiry threshold disabled", details: `{"address": "example.com:443", "min_days_remaining": 0}`, wantSNI: "example.com"},
+		{name: "empty details", details: "", wantError: true},
+		{name: "missing port", details: "example.com", wantError: true},
+		{name: "unsupported starttls", details: `{"address": "example.com:21", "starttls": "ftp"}`, wantError: true},
+		{name: "missing CA file", details: `{"address": "example.com:443", "ca_file": "/nonexistent/ca.pem"}`, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewTLSChecker(tt.details)
+			if tt.wantError {
+				require.Error(t, err)
+				assert.Nil(t, c)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSNI, c.config.ServerName)
+			assert.Equal(t, tt.wantMinDays, *c.config.MinDaysRemaining)
+		})
+	}
+}
+
+func TestTLSCheckerCheck(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	addr := server.Listener.Addr().String()
+	caFile := writeServerCA(t, server)
+
+	tests := []struct {
+		name      string
+		details   string
+		wantOK    bool
+		wantValid bool
+	}{
+		{
+			name:      "untrusted chain",
+			details:   fmt.Sprintf(`{"address": %q, "server_name": "example.com"}`, addr),
+			wantOK:    false,
+			wantValid: false,
+		},
+		{
+			name:      "untrusted chain with skip_verify",
+			details:   fmt.Sprintf(`{"address": %q, "server_name": "example.com", "skip_verify": true}`, addr),
+			wantOK:    true,
+			wantValid: false,
+		},
+		{
+			name:      "trusted via CA file",
+			details:   fmt.Sprintf(`{"address": %q, "server_name": "example.com", "ca_file": %q}`, addr, caFile),
+			wantOK:    true,
+			wantValid: true,
+		},
+		{
+			name:      "name mismatch",
+			details:   fmt.Sprintf(`{"address": %q, "server_name": "other.test", "ca_file": %q}`, addr, caFile),
+			wantOK:    false,
+			wantValid: false,
+		},
+		{
+			name: "below expiry threshold",
+			details: fmt.Sprintf(`{"address": %q, "server_name": "example.com", "ca_file": %q, "min_days_remaining": 1000000}`,
+				addr, caFile),
+			wantOK:    false,
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewTLSChecker(tt.details)
+			require.NoError(t, err)
+
+			ok, msg := c.Check(context.Background())
+			assert.Equal(t, tt.wantOK, ok, msg)
+
+			var resp TLSResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+			assert.Equal(t, tt.wantValid, resp.Valid)
+			assert.Contains(t, resp.SANs, "example.com")
+			assert.Positive(t, resp.DaysUntilExpiry)
+			assert.NotEmpty(t, resp.Chain)
+
+			if !tt.wantValid {
+				assert.NotEmpty(t, resp.ValidationError)
+			}
+		})
+	}
+}
+
+func TestTLSCheckerSMTPStartTLS(t *testing.T) {
+	tlsServer := httptest.NewUnstartedServer(http.NotFoundHandler())
+	tlsServer.StartTLS()
+
+	defer tlsServer.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer func() { _ = listener.Close() }()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		defer func() { _ = conn.Close() }()
+
+		reader := bufio.NewReader(conn)
+
+		_, _ = fmt.Fprint(conn, "220 mail.example.com ESMTP\r\n")
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			switch {
+			case strings.HasPrefix(line, "EHLO"):
+				_, _ = fmt.Fprint(conn, "250-mail.example.com\r\n250 STARTTLS\r\n")
+			case strings.HasPrefix(line, "STARTTLS"):
+				_, _ = fmt.Fprint(conn, "220 Ready to start TLS\r\n")
+
+				_ = tls.Server(conn, tlsServer.TLS).Handshake()
+
+				return
+			}
+		}
+	}()
+
+	details := fmt.Sprintf(`{"address": %q, "server_name": "example.com", "starttls": "smtp", "ca_file": %q}`,
+		listener.Addr().String(), writeServerCA(t, tlsServer))
+
+	c, err := NewTLSChecker(details)
+	require.NoError(t, err)
+
+	ok, msg := c.Check(context.Background())
+	assert.True(t, ok, msg)
+}
@@ -41,6 +41,10 @@ type Server struct {
 	done         chan struct{}
 	config       *ServerConfig
 	connections  map[string]*CheckerConnection
+	scheduled    map[string]*scheduledCheck
+	// newSweepService creates the sweep service; tests replace it to avoid
+	// real scans.
+	newSweepService func(*models.Config) (Service, error)
 }
 type Duration time.Duration
 
@@ -66,12 +70,19 @@ type CheckerConfig struct {
 	ListenAddr string          `json:"listen_addr,omitempty"`
 	Additional json.RawMessage `json:"additional,omitempty"`
 	Details    json.RawMessage `json:"details,omitempty"`
+	// Interval makes the agent run the check on its own schedule and answer
+	// status requests with the latest result instead of running it on demand.
+	Interval Duration `json:"interval,omitempty"`
+	// Security overrides the agent's security settings for connections to
+	// external (grpc) checkers.
+	Security *models.SecurityConfig `json:"security,omitempty"`
 }
 
 // ServerConfig holds the configuration for the agent server.
 type ServerConfig struct {
 	ListenAddr string                 `json:"listen_addr"`
 	Security   *models.SecurityConfig `json:"security"`
+	PluginsDir string                 `json:"plugins_dir"` // directory of executables allowed for exec checks
 }
 
 type CheckerConnection struct {
@@ -0,0 +1,378 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent pkg/agent/udp_checker.go
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	defaultUDPTimeout    = 2 * time.Second
+	defaultUDPRetries    = 2
+	defaultSNMPCommunity = "public"
+	maxUDPPacketBytes    = 65535
+
+	udpProbeDNS    = "dns"
+	udpProbeNTP    = "ntp"
+	udpProbeSNMP   = "snmp"
+	udpProbeSyslog = "syslog"
+	udpProbeEcho   = "echo"
+
+	ntpPacketSize   = 48
+	ntpModeMask     = 0x07
+	ntpModeServer   = 4
+	ntpClientHeader = 0x23 // LI 0, version 4, mode 3 (client)
+
+	sysDescrOID = ".1.3.6.1.2.1.1.1.0"
+)
+
+// UDPCheckerConfig describes the datagram a UDPChecker sends. Probe selects a
+// built-in request (dns, ntp, snmp, syslog or echo); otherwise Payload or
+// PayloadHex is sent and any reply, optionally containing Expect, passes.
+type UDPCheckerConfig struct {
+	Address    string   `json:"address"`
+	Probe      string   `json:"probe,omitempty"`
+	Payload    string   `json:"payload,omitempty"`
+	PayloadHex string   `json:"payload_hex,omitempty"`
+	Expect     string   `json:"expect,omitempty"`
+	Community  string   `json:"community,omitempty"` // snmp probe, default "public"
+	Retries    *int     `json:"retries,omitempty"`   // default 2, 0 sends a single attempt
+	Timeout    Duration `json:"timeout,omitempty"`   // per attempt
+}
+
+// UDPChecker sends a request datagram and waits for a reply.
+type UDPChecker struct {
+	config  UDPCheckerConfig
+	payload []byte
+}
+
+// UDPResponse defines the structure of the UDP check result.
+type UDPResponse struct {
+	Address      string `json:"address"`
+	Probe        string `json:"probe,omitempty"`
+	ResponseTime int64  `json:"response_time"`
+	ResponseSize int    `json:"response_size"`
+	Attempts     int    `json:"attempts"`
+	Available    bool   `json:"available"`
+	Error        string `json:"error,omitempty"`
+}
+
+// NewUDPChecker creates a UDPChecker from the check details, which are either
+// "probe:host[:port]" (e.g. "ntp:pool.ntp.org") or a JSON encoded UDPCheckerConfig.
+func NewUDPChecker(details string) (*UDPChecker, error) {
+	details = strings.TrimSpace(details)
+	if details == "" {
+		return nil, errDetailsRequiredUDP
+	}
+
+	var cfg UDPCheckerConfig
+
+	if strings.HasPrefix(details, "{") {
+		if err := json.Unmarshal([]byte(details), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse udp check details: %w", err)
+		}
+	} else {
+		probe, address, found := strings.Cut(details, ":")
+		if !found {
+			return nil, fmt.Errorf("%w: %s", errDetailsRequiredUDP, details)
+		}
+
+		cfg.Probe, cfg.Address = probe, address
+	}
+
+	c := &UDPChecker{config: cfg}
+	if err := c.applyDefaults(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *UDPChecker) applyDefaults() error {
+	if c.config.Timeout == 0 {
+		c.config.Timeout = Duration(defaultUDPTimeout)
+	}
+
+	if c.config.Retries == nil {
+		retries := defaultUDPRetries
+		c.config.Retries = &retries
+	}
+
+	if c.config.Community == "" {
+		c.config.Community = defaultSNMPCommunity
+	}
+
+	c.config.Probe = strings.ToLower(c.config.Probe)
+
+	port, err := udpProbePort(c.config.Probe)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := net.SplitHostPort(c.config.Address); err != nil {
+		if port == 0 {
+			return fmt.Errorf("%w: %s", errUDPPortRequired, c.config.Address)
+		}
+
+		c.config.Address = net.JoinHostPort(c.config.Address, strconv.Itoa(port))
+	}
+
+	if c.config.Probe == "" {
+		if c.config.PayloadHex == "" {
+			c.payload = []byte(c.config.Payload)
+
+			return nil
+		}
+
+		if c.payload, err = hex.DecodeString(c.config.PayloadHex); err != nil {
+			return fmt.Errorf("invalid payload_hex: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// udpProbePort returns the well-known port of a built-in probe, or 0 for a
+// custom payload.
+func udpProbePort(probe string) (int, error) {
+	switch probe {
+	case "":
+		return 0, nil
+	case udpProbeDNS:
+		return 53, nil //nolint:mnd // well-known port
+	case udpProbeNTP:
+		return 123, nil //nolint:mnd // well-known port
+	case udpProbeSNMP:
+		return 161, nil //nolint:mnd // well-known port
+	case udpProbeSyslog:
+		return 514, nil //nolint:mnd // well-known port
+	case udpProbeEcho:
+		return 7, nil //nolint:mnd // well-known port
+	default:
+		return 0, fmt.Errorf("%w: %s", errUnsupportedUDPProbe, probe)
+	}
+}
+
+// Check sends the probe, retrying on timeouts, and validates the reply.
+func (c *UDPChecker) Check(ctx context.Context) (isAvailable bool, statusMsg string) {
+	resp := &UDPResponse{Address: c.config.Address, Probe: c.config.Probe}
+
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		resp.Attempts = attempt
+
+		err = c.attempt(ctx, resp)
+		if !isTimeout(err) || attempt > *c.config.Retries || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Available = true
+	}
+
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("failed to marshal udp response: %v", err)
+
+		return false, fmt.Sprintf(`{"error": "%v"}`, err)
+	}
+
+	return resp.Available, string(jsonResp)
+}
+
+func (c *UDPChecker) attempt(ctx context.Context, resp *UDPResponse) error {
+	var dialer net.Dialer
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout))
+	defer cancel()
+
+	// A connected socket surfaces ICMP port unreachable as ECONNREFUSED.
+	conn, err := dialer.DialContext(ctx, "udp", c.config.Address)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	request, validate, err := c.request()
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	if _, err := conn.Write(request); err != nil {
+		return fmt.Errorf("failed to send probe: %w", err)
+	}
+
+	reply := make([]byte, maxUDPPacketBytes)
+
+	n, err := conn.Read(reply)
+
+	resp.ResponseTime = time.Since(start).Nanoseconds()
+
+	// Syslog never answers: silence means nothing rejected the datagram,
+	// while a closed port is reported through ICMP as connection refused.
+	if c.config.Probe == udpProbeSyslog {
+		if err == nil || isTimeout(err) {
+			return nil
+		}
+
+		return fmt.Errorf("syslog message rejected: %w", err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("no reply: %w", err)
+	}
+
+	resp.ResponseSize = n
+
+	return validate(request, reply[:n])
+}
+
+// request builds the datagram to send and the function that validates the reply.
+func (c *UDPChecker) request() ([]byte, func(request, reply []byte) error, error) {
+	switch c.config.Probe {
+	case udpProbeDNS:
+		query, err := buildDNSQuery(newDNSQueryID(), ".", dnsmessage.TypeNS)
+
+		return query, validateDNSReply, err
+	case udpProbeNTP:
+		request := make([]byte, ntpPacketSize)
+		request[0] = ntpClientHeader
+
+		return request, validateNTPReply, nil
+	case udpProbeSNMP:
+		return c.snmpRequest()
+	case udpProbeSyslog:
+		hostname, _ := os.Hostname()
+
+		// RFC 5424 message, facility user (1), severity info (6).
+		message := fmt.Sprintf("<14>1 %s %s serviceradar - - - ServiceRadar UDP check",
+			time.Now().UTC().Format(time.RFC3339), hostname)
+
+		return []byte(message), nil, nil
+	case udpProbeEcho:
+		return []byte("serviceradar-udp-check"), validateEchoReply, nil
+	default:
+		return c.payload, c.validateExpect, nil
+	}
+}
+
+func (c *UDPChecker) validateExpect(_, reply []byte) error {
+	if c.config.Expect != "" && !bytes.Contains(reply, []byte(c.config.Expect)) {
+		return fmt.Errorf("%w: %q not found", errUnexpectedUDPReply, c.config.Expect)
+	}
+
+	return nil
+}
+
+func validateDNSReply(request, reply []byte) error {
+	var header dnsmessage.Parser
+
+	h, err := header.Start(reply)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errUnexpectedUDPReply, err)
+	}
+
+	if !h.Response || h.ID != binary.BigEndian.Uint16(request) {
+		return fmt.Errorf("%w: not a DNS response to the query", errUnexpectedUDPReply)
+	}
+
+	return nil
+}
+
+func validateNTPReply(_, reply []byte) error {
+	if len(reply) < ntpPacketSize || reply[0]&ntpModeMask != ntpModeServer {
+		return fmt.Errorf("%w: not an NTP server reply", errUnexpectedUDPReply)
+	}
+
+	return nil
+}
+
+func validateEchoReply(request, reply []byte) error {
+	if !bytes.Equal(request, reply) {
+		return fmt.Errorf("%w: echo reply differs from request", errUnexpectedUDPReply)
+	}
+
+	return nil
+}
+
+// snmpRequest builds an SNMPv2c GET for sysDescr.0. Agents silently drop
+// requests with an unknown community, so any response proves the community works.
+func (c *UDPChecker) snmpRequest() ([]byte, func(request, reply []byte) error, error) {
+	packet := &gosnmp.SnmpPacket{
+		Version:   gosnmp.Version2c,
+		Community: c.config.Community,
+		PDUType:   gosnmp.GetRequest,
+		RequestID: uint32(time.Now().UnixNano() & math.MaxInt32), //nolint:gosec // masked to the Integer32 range of request-id
+		Variables: []gosnmp.SnmpPDU{{Name: sysDescrOID, Type: gosnmp.Null}},
+	}
+
+	request, err := packet.MarshalMsg()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build SNMP request: %w", err)
+	}
+
+	validate := func(_, reply []byte) error {
+		// Decoding fills in defaults on the receiver, so gosnmp.Default is not
+		// safe to share between concurrent checks.
+		decoded, err := (&gosnmp.GoSNMP{Version: gosnmp.Version2c}).SnmpDecodePacket(reply)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errUnexpectedUDPReply, err)
+		}
+
+		if decoded.PDUType != gosnmp.GetResponse || decoded.RequestID != packet.RequestID ||
+			decoded.Community != c.config.Community {
+			return fmt.Errorf("%w: not a response to the SNMP request", errUnexpectedUDPReply)
+		}
+
+		return nil
+	}
+
+	return request, validate, nil
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startFakeUDPServer replies to each datagram with reply(request); a nil
+// reply sends nothing.
+func startFakeUDPServer(t *testing.T, reply func(request []byte) []byte) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, maxUDPPacketBytes)
+
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			if out := reply(buf[:n]); out != nil {
+				_, _ = conn.WriteTo(out, addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func fakeNTPReply(_ []byte) []byte {
+	reply := make([]byte, ntpPacketSize)
+	reply[0] = 0x24 // LI 0, version 4, mode 4 (server)
+	reply[1] = 2    // stratum
+
+	return reply
+}
+
+// fakeSNMPReply answers with sysDescr.0 and the request id plus idOffset.
+func fakeSNMPReply(community string, idOffset uint32) func([]byte) []byte {
+	return func(request []byte) []byte {
+		decoded, err := (&gosnmp.GoSNMP{Version: gosnmp.Version2c}).SnmpDecodePacket(request)
+		if err != nil {
+			return nil
+		}
+
+		packet := &gosnmp.SnmpPacket{
+			Version:   gosnmp.Version2c,
+			Community: community,
+			PDUType:   gosnmp.GetResponse,
+			RequestID: decoded.RequestID + idOffset,
+			Variables: []gosnmp.SnmpPDU{{Name: sysDescrOID, Type: gosnmp.OctetString, Value: []byte("Linux router")}},
+		}
+
+		reply, _ := packet.MarshalMsg()
+
+		return reply
+	}
+}
+
+// closedUDPPort returns a local address with nothing listening on it.
+func closedUDPPort(t *testing.T) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	address := conn.LocalAddr().String()
+	require.NoError(t, conn.Close())
+
+	return address
+}
+
+func TestNewUDPChecker(t *testing.T) {
+	tests := []struct {
+		name        string
+		details     string
+		wantAddress string
+		wantError   error
+	}{
+		{name: "probe with default port", details: "ntp:pool.ntp.org", wantAddress: "pool.ntp.org:123"},
+		{name: "probe with port", details: "dns:10.0.0.53:5353", wantAddress: "10.0.0.53:5353"},
+		{name: "custom payload", details: `{"address": "10.0.0.1:1812", "payload_hex": "0102"}`, wantAddress: "10.0.0.1:1812"},
+		{name: "custom payload without port", details: `{"address": "10.0.0.1", "payload": "x"}`, wantError: errUDPPortRequired},
+		{name: "unknown probe", details: "radius:10.0.0.1", wantError: errUnsupportedUDPProbe},
+		{name: "no probe", details: "10.0.0.1", wantError: errDetailsRequiredUDP},
+		{name: "empty", details: "", wantError: errDetailsRequiredUDP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewUDPChecker(tt.details)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAddress, c.config.Address)
+		})
+	}
+}
+
+func TestUDPCheckerCheck(t *testing.T) {
+	silent := startFakeUDPServer(t, func([]byte) []byte { return nil })
+	echo := startFakeUDPServer(t, func(request []byte) []byte { return request })
+	radius := startFakeUDPServer(t, func([]byte) []byte { return []byte("\x02\x01access-accept") })
+
+	tests := []struct {
+		name         string
+		details      string
+		wantOK       bool
+		wantSize     int
+		wantAttempts int
+	}{
+		{name: "dns", details: "dns:" + startFakeResolver(t), wantOK: true, wantAttempts: 1},
+		{name: "ntp", details: "ntp:" + startFakeUDPServer(t, fakeNTPReply), wantOK: true, wantSize: ntpPacketSize, wantAttempts: 1},
+		{name: "ntp wrong reply", details: "ntp:" + echo, wantOK: false, wantSize: ntpPacketSize, wantAttempts: 1},
+		{name: "snmp", details: "snmp:" + startFakeUDPServer(t, fakeSNMPReply("public", 0)), wantOK: true, wantAttempts: 1},
+		{
+			name:         "snmp other community",
+			details:      fmt.Sprintf(`{"address": %q, "probe": "snmp", "community": "private"}`, startFakeUDPServer(t, fakeSNMPReply("public", 0))),
+			wantOK:       false,
+			wantAttempts: 1,
+		},
+		{name: "snmp other request id", details: "snmp:" + startFakeUDPServer(t, fakeSNMPReply("public", 1)), wantOK: false, wantAttempts: 1},
+		{name: "snmp echoed request", details: "snmp:" + echo, wantOK: false, wantAttempts: 1},
+		{name: "echo", details: "echo:" + echo, wantOK: true, wantSize: len("serviceradar-udp-check"), wantAttempts: 1},
+		{
+			name:         "custom payload expect",
+			details:      fmt.Sprintf(`{"address": %q, "payload_hex": "01", "expect": "access-accept"}`, radius),
+			wantOK:       true,
+			wantSize:     15,
+			wantAttempts: 1,
+		},
+		{
+			name:         "custom payload mismatch",
+			details:      fmt.Sprintf(`{"address": %q, "payload": "x", "expect": "access-reject"}`, radius),
+			wantOK:       false,
+			wantSize:     15,
+			wantAttempts: 1,
+		},
+		{name: "syslog accepted", details: `{"address": "` + silent + `", "probe": "syslog", "timeout": "50ms"}`, wantOK: true, wantAttempts: 1},
+		{name: "syslog port closed", details: "syslog:" + closedUDPPort(t), wantOK: false, wantAttempts: 1},
+		{
+			name:         "no reply retries",
+			details:      `{"address": "` + silent + `", "probe": "echo", "retries": 1, "timeout": "50ms"}`,
+			wantOK:       false,
+			wantAttempts: 2,
+		},
+		{
+			name:         "no reply without retries",
+			details:      `{"address": "` + silent + `", "probe": "echo", "retries": 0, "timeout": "50ms"}`,
+			wantOK:       false,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewUDPChecker(tt.details)
+			require.NoError(t, err)
+
+			ok, msg := c.Check(context.Background())
+			assert.Equal(t, tt.wantOK, ok, msg)
+
+			var resp UDPResponse
+			require.NoError(t, json.Unmarshal([]byte(msg), &resp))
+			assert.Equal(t, tt.wantAttempts, resp.Attempts)
+
+			if tt.wantSize > 0 {
+				assert.Equal(t, tt.wantSize, resp.ResponseSize)
+			}
+
+			if tt.wantOK {
+				assert.Empty(t, resp.Error)
+				assert.Positive(t, resp.ResponseTime)
+			} else {
+				assert.NotEmpty(t, resp.Error)
+			}
+		})
+	}
+}
@@ -61,6 +61,12 @@ const (
 	Error   AlertLevel = "error"
 )
 
+// Titles of the service state change alerts.
+const (
+	ServiceDownTitle      = "Service Down"
+	ServiceRecoveredTitle = "Service Recovered"
+)
+
 type WebhookAlert struct {
 	Level       AlertLevel     `json:"level"`
 	Title       string         `json:"title"`
@@ -68,6 +74,7 @@ type WebhookAlert struct {
 	Timestamp   string         `json:"timestamp"`
 	NodeID      string         `json:"node_id"`
 	ServiceName string         `json:"service_name,omitempty"`
+	AgentName   string         `json:"agent_name,omitempty"`
 	Details     map[string]any `json:"details,omitempty"`
 }
 
@@ -76,6 +83,7 @@ type AlertKey struct {
 	NodeID      string
 	Title       string
 	ServiceName string
+	AgentName   string
 }
 
 type WebhookAlerter struct {
@@ -83,7 +91,7 @@ type WebhookAlerter struct {
 	client             *http.Client
 	LastAlertTimes     map[AlertKey]time.Time
 	NodeDownStates     map[string]bool
-	ServiceAlertStates map[string]bool
+	ServiceAlertStates map[AlertKey]bool
 	Mu                 sync.RWMutex
 	bufferPool         *sync.Pool
 }
@@ -121,8 +129,9 @@ func NewWebhookAlerter(config WebhookConfig) *WebhookAlerter {
 		client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		LastAlertTimes: make(map[AlertKey]time.Time),
-		NodeDownStates: make(map[string]bool),
+		LastAlertTimes:     make(map[AlertKey]time.Time),
+		NodeDownStates:     make(map[string]bool),
+		ServiceAlertStates: make(map[AlertKey]bool),
 		bufferPool: &sync.Pool{
 			New: func() interface{} {
 				return new(bytes.Buffer)
@@ -131,11 +140,17 @@ func NewWebhookAlerter(config WebhookConfig) *WebhookAlerter {
 	}
 }
 
-func (w *WebhookAlerter) MarkServiceAsRecovered(nodeID string) {
+// MarkServiceAsRecovered allows the next "Service Down" alert for the service
+// reported by the agent.
+func (w *WebhookAlerter) MarkServiceAsRecovered(nodeID, agentName, serviceName string) {
 	w.Mu.Lock()
 	defer w.Mu.Unlock()
 
-	w.ServiceAlertStates[nodeID] = false
+	delete(w.ServiceAlertStates, serviceDownKey(nodeID, agentName, serviceName))
+}
+
+func serviceDownKey(nodeID, agentName, serviceName string) AlertKey {
+	return AlertKey{NodeID: nodeID, Title: ServiceDownTitle, ServiceName: serviceName, AgentName: agentName}
 }
 
 func (w *WebhookAlerter) IsEnabled() bool {
@@ -185,6 +200,19 @@ func (w *WebhookAlerter) Alert(ctx context.Context, alert *WebhookAlert) error {
 		w.Mu.Unlock()
 	}
 
+	// Likewise, send one "Service Down" alert until the service recovers.
+	if alert.Title == ServiceDownTitle {
+		w.Mu.RLock()
+		down := w.ServiceAlertStates[serviceDownKey(alert.NodeID, alert.AgentName, alert.ServiceName)]
+		w.Mu.RUnlock()
+
+		if down {
+			log.Printf("Skipping duplicate 'Service Down' alert for service %s on node: %s", alert.ServiceName, alert.NodeID)
+
+			return nil
+		}
+	}
+
 	// Always check cooldown (using the correct AlertKey, with ServiceName).
 	if err := w.CheckCooldown(alert.NodeID, alert.Title, alert.ServiceName); err != nil {
 		return err
@@ -199,7 +227,19 @@ func (w *WebhookAlerter) Alert(ctx context.Context, alert *WebhookAlert) error {
 		return fmt.Errorf("failed to prepare payload: %w", err)
 	}
 
-	return w.sendRequest(ctx, payload)
+	if err := w.sendRequest(ctx, payload); err != nil {
+		return err
+	}
+
+	// The service only counts as down once its alert went out, so an alert
+	// held back by the cooldown or a failed request is sent again.
+	if alert.Title == ServiceDownTitle {
+		w.Mu.Lock()
+		w.ServiceAlertStates[serviceDownKey(alert.NodeID, alert.AgentName, alert.ServiceName)] = true
+		w.Mu.Unlock()
+	}
+
+	return nil
 }
 
 func (w *WebhookAlerter) MarkNodeAsRecovered(nodeID string) {
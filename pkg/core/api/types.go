@@ -31,8 +31,9 @@ type ServiceStatus struct {
 	Name      string          `json:"name"`
 	Available bool            `json:"available"`
 	Message   string          `json:"message"`
-	Type      string          `json:"type"`    // e.g., "process", "port", "blockchain", etc.
-	Details   json.RawMessage `json:"details"` // Flexible field for service-specific data
+	Type      string          `json:"type"`                 // e.g., "process", "port", "blockchain", etc.
+	Details   json.RawMessage `json:"details"`              // Flexible field for service-specific data
+	AgentName string          `json:"agent_name,omitempty"` // Agent that ran the check
 }
 
 type NodeStatus struct {
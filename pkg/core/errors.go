@@ -23,4 +23,5 @@ var (
 	errDatabaseError      = errors.New("database error")
 	errInvalidSweepData   = errors.New("invalid sweep data")
 	errFailedToSendAlerts = errors.New("failed to send alerts")
+	errHostCheckFailed    = errors.New("host check failed")
 )
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/carverauto/serviceradar/pkg/db"
+	"github.com/carverauto/serviceradar/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProcessHostMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := db.NewMockService(ctrl)
+	server := &Server{db: mockDB}
+	now := time.Now()
+
+	details := json.RawMessage(`{
+		"cpu": {"usage_percent": 12.5, "cores": 4},
+		"memory": {"total_bytes": 2048, "available_bytes": 1024, "used_percent": 50},
+		"load": {"load1": 0.5, "load5": 0.25, "load15": 0.1},
+		"disks": [{"mount_point": "/", "total_bytes": 100, "free_bytes": 40, "used_percent": 60}],
+		"available": true
+	}`)
+
+	stored := map[string]*db.TimeseriesMetric{}
+
+	mockDB.EXPECT().StoreMetric("node1", gomock.Any()).DoAndReturn(
+		func(_ string, metric *db.TimeseriesMetric) error {
+			stored[metric.Name] = metric
+
+			return nil
+		}).Times(8)
+
+	svc := &proto.ServiceStatus{ServiceName: "host", ServiceType: hostService, AgentName: "web-1"}
+	require.NoError(t, server.processHostMetrics("node1", svc, details, now))
+
+	assert.Equal(t, "12.5", stored["cpu_usage_percent"].Value)
+	assert.Equal(t, "50", stored["memory_used_percent"].Value)
+	assert.Equal(t, "1024", stored["memory_available_bytes"].Value)
+	assert.Equal(t, "0.25", stored["load5"].Value)
+	assert.Equal(t, "60", stored["disk_used_percent"].Value)
+	assert.Equal(t, map[string]interface{}{"mount_point": "/", "service_name": "host", "agent_name": "web-1"},
+		stored["disk_free_bytes"].Metadata)
+	assert.Equal(t, map[string]interface{}{"service_name": "host", "agent_name": "web-1"}, stored["load1"].Metadata)
+	assert.Equal(t, hostService, stored["load1"].Type)
+	assert.Equal(t, now, stored["load1"].Timestamp)
+}
+
+func TestProcessHostMetricsCheckError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := &Server{db: db.NewMockService(ctrl)}
+
+	svc := &proto.ServiceStatus{ServiceName: "host", ServiceType: hostService}
+
+	err := server.processHostMetrics("node1", svc,
+		json.RawMessage(`{"available": false, "error": "failed to read cpu stats"}`), time.Now())
+	require.ErrorIs(t, err, errHostCheckFailed)
+}
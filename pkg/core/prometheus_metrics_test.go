@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/carverauto/serviceradar/pkg/db"
+	"github.com/carverauto/serviceradar/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProcessPrometheusMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := db.NewMockService(ctrl)
+	server := &Server{db: mockDB}
+	now := time.Now()
+
+	details := json.RawMessage(`{
+		"url": "http://10.0.0.1:9100/metrics",
+		"series_count": 120,
+		"series": [
+			{"name": "up", "labels": {"job": "api"}, "value": 1},
+			{"name": "queue_depth", "value": 42.5}
+		],
+		"available": false,
+		"error": "metric assertion failed: queue_depth: queue_depth = 42.5 is out of bounds"
+	}`)
+
+	var stored []*db.TimeseriesMetric
+
+	mockDB.EXPECT().StoreMetric("node1", gomock.Any()).DoAndReturn(
+		func(_ string, metric *db.TimeseriesMetric) error {
+			stored = append(stored, metric)
+
+			return nil
+		}).Times(2)
+
+	svc := &proto.ServiceStatus{ServiceName: "api-metrics", ServiceType: prometheusService, AgentName: "api-1"}
+	server.processServiceMetrics("node1", svc, details, now)
+
+	require.Len(t, stored, 2)
+	assert.Equal(t, &db.TimeseriesMetric{
+		Name:      "up",
+		Value:     "1",
+		Type:      prometheusService,
+		Timestamp: now,
+		Metadata: map[string]interface{}{
+			"url":          "http://10.0.0.1:9100/metrics",
+			"labels":       map[string]string{"job": "api"},
+			"service_name": "api-metrics",
+			"agent_name":   "api-1",
+		},
+	}, stored[0])
+	assert.Equal(t, "queue_depth", stored[1].Name)
+	assert.Equal(t, "42.5", stored[1].Value)
+}
@@ -47,6 +47,9 @@ const (
 	defaultDBPath            = "/var/lib/serviceradar/serviceradar.db"
 	statusUnknown            = "unknown"
 	sweepService             = "sweep"
+	snmpService              = "snmp"
+	hostService              = "host"
+	prometheusService        = "prometheus"
 	dailyCleanupInterval     = 24 * time.Hour
 	monitorInterval          = 30 * time.Second
 )
@@ -447,6 +450,7 @@ func (s *Server) processStatusReport(
 	apiStatus := s.createNodeStatus(req, now)
 
 	s.processServices(req.PollerId, apiStatus, req.Services, now)
+	s.processServiceStateChanges(ctx, req.PollerId, apiStatus.Services, now)
 
 	if err := s.updateNodeState(ctx, req.PollerId, apiStatus, currentState, now); err != nil {
 		return nil, err
@@ -476,6 +480,7 @@ func (s *Server) processServices(pollerID string, apiStatus *api.NodeStatus, ser
 			Type:      svc.ServiceType,
 			Available: svc.Available,
 			Message:   svc.Message,
+			AgentName: svc.AgentName,
 		}
 
 		if !svc.Available {
@@ -511,13 +516,7 @@ func (s *Server) processServices(pollerID string, apiStatus *api.NodeStatus, ser
 
 		apiService.Details = details // Now details is in scope
 
-		if svc.ServiceType == "snmp" {
-			log.Printf("Found SNMP service, attempting to process metrics for node %s", pollerID)
-
-			if err := s.processSNMPMetrics(pollerID, details, now); err != nil { // details is also available here
-				log.Printf("Error processing SNMP metrics for node %s: %v", pollerID, err)
-			}
-		}
+		s.processServiceMetrics(pollerID, svc, details, now)
 
 		if err := s.handleService(pollerID, &apiService, now); err != nil {
 			log.Printf("Error handling service %s: %v", svc.ServiceName, err)
@@ -529,6 +528,121 @@ func (s *Server) processServices(pollerID string, apiStatus *api.NodeStatus, ser
 	apiStatus.IsHealthy = allServicesAvailable
 }
 
+// processServiceMetrics stores the metrics carried by service types that report them.
+func (s *Server) processServiceMetrics(pollerID string, svc *proto.ServiceStatus, details json.RawMessage, now time.Time) {
+	switch svc.ServiceType {
+	case snmpService:
+		log.Printf("Found SNMP service, attempting to process metrics for node %s", pollerID)
+
+		if err := s.processSNMPMetrics(pollerID, details, now); err != nil {
+			log.Printf("Error processing SNMP metrics for node %s: %v", pollerID, err)
+		}
+	case hostService:
+		if err := s.processHostMetrics(pollerID, svc, details, now); err != nil {
+			log.Printf("Error processing host metrics for node %s: %v", pollerID, err)
+		}
+	case prometheusService:
+		s.processPrometheusMetrics(pollerID, svc, details, now)
+	}
+}
+
+// serviceMetricMetadata identifies the check a metric came from, so that the
+// same check type on different agents behind a poller yields separate series.
+func serviceMetricMetadata(svc *proto.ServiceStatus) map[string]interface{} {
+	metadata := map[string]interface{}{"service_name": svc.ServiceName}
+
+	if svc.AgentName != "" {
+		metadata["agent_name"] = svc.AgentName
+	}
+
+	return metadata
+}
+
+// processPrometheusMetrics stores the series forwarded by the agent Prometheus
+// checker. Series are stored even when an assertion failed, since they are
+// usually what explains the failure.
+func (s *Server) processPrometheusMetrics(
+	nodeID string, svc *proto.ServiceStatus, details json.RawMessage, timestamp time.Time) {
+	var promData PrometheusMetricsData
+
+	if err := json.Unmarshal(details, &promData); err != nil {
+		log.Printf("Error parsing Prometheus data for node %s: %v", nodeID, err)
+
+		return
+	}
+
+	for _, series := range promData.Series {
+		metadata := serviceMetricMetadata(svc)
+		metadata["url"] = promData.URL
+		metadata["labels"] = series.Labels
+
+		metric := &db.TimeseriesMetric{
+			Name:      series.Name,
+			Value:     fmt.Sprintf("%v", series.Value),
+			Type:      prometheusService,
+			Timestamp: timestamp,
+			Metadata:  metadata,
+		}
+
+		if err := s.db.StoreMetric(nodeID, metric); err != nil {
+			log.Printf("Error storing Prometheus metric %s for node %s: %v", series.Name, nodeID, err)
+		}
+	}
+}
+
+// processHostMetrics extracts and stores host resource metrics from service
+// details, tagged with the agent and service that reported them.
+func (s *Server) processHostMetrics(
+	nodeID string, svc *proto.ServiceStatus, details json.RawMessage, timestamp time.Time) error {
+	var hostData HostMetricsData
+
+	if err := json.Unmarshal(details, &hostData); err != nil {
+		return fmt.Errorf("failed to parse host data: %w", err)
+	}
+
+	if hostData.Error != "" {
+		return fmt.Errorf("%w: %s", errHostCheckFailed, hostData.Error)
+	}
+
+	source := serviceMetricMetadata(svc)
+
+	metrics := []*db.TimeseriesMetric{
+		hostMetric("cpu_usage_percent", hostData.CPU.UsagePercent, timestamp, source),
+		hostMetric("memory_used_percent", hostData.Memory.UsedPercent, timestamp, source),
+		hostMetric("memory_available_bytes", hostData.Memory.AvailableBytes, timestamp, source),
+		hostMetric("load1", hostData.Load.Load1, timestamp, source),
+		hostMetric("load5", hostData.Load.Load5, timestamp, source),
+		hostMetric("load15", hostData.Load.Load15, timestamp, source),
+	}
+
+	for _, disk := range hostData.Disks {
+		metadata := serviceMetricMetadata(svc)
+		metadata["mount_point"] = disk.MountPoint
+
+		metrics = append(metrics,
+			hostMetric("disk_used_percent", disk.UsedPercent, timestamp, metadata),
+			hostMetric("disk_free_bytes", disk.FreeBytes, timestamp, metadata))
+	}
+
+	for _, metric := range metrics {
+		if err := s.db.StoreMetric(nodeID, metric); err != nil {
+			log.Printf("Error storing host metric %s for node %s: %v", metric.Name, nodeID, err)
+		}
+	}
+
+	return nil
+}
+
+func hostMetric(name string, value interface{}, timestamp time.Time, metadata map[string]interface{}) *db.TimeseriesMetric {
+	return &db.TimeseriesMetric{
+		Name:      name,
+		Value:     fmt.Sprintf("%v", value),
+		Type:      hostService,
+		Timestamp: timestamp,
+		Metadata:  metadata,
+	}
+}
+
 // processSNMPMetrics extracts and stores SNMP metrics from service details.
 func (s *Server) processSNMPMetrics(nodeID string, details json.RawMessage, timestamp time.Time) error {
 	log.Printf("Processing SNMP metrics for node %s", nodeID)
@@ -1120,7 +1234,6 @@ func (s *Server) handleNodeRecovery(ctx context.Context, nodeID string, apiStatu
 	for _, webhook := range s.webhooks {
 		if alerter, ok := webhook.(*alerts.WebhookAlerter); ok {
 			alerter.MarkNodeAsRecovered(nodeID)
-			alerter.MarkServiceAsRecovered(nodeID)
 		}
 	}
 
@@ -1175,6 +1288,15 @@ func (s *Server) ReportStatus(ctx context.Context, req *proto.PollerStatusReques
 	}
 
 	now := time.Unix(req.Timestamp, 0)
+
+	if req.Backfill {
+		if err := s.processBackfill(req, now); err != nil {
+			return nil, fmt.Errorf("failed to process backfilled status report: %w", err)
+		}
+
+		return &proto.PollerStatusResponse{Received: true}, nil
+	}
+
 	timestamp := time.Now()
 	responseTime := timestamp.Sub(now).Nanoseconds()
 
@@ -1188,48 +1310,81 @@ func (s *Server) ReportStatus(ctx context.Context, req *proto.PollerStatusReques
 		return nil, fmt.Errorf("failed to process status report: %w", err)
 	}
 
-	if s.metrics != nil {
-		for _, service := range req.Services {
-			if service.ServiceType != "icmp" {
-				continue
-			}
+	s.processICMPMetrics(req.PollerId, req.Services, now)
 
-			// Parse the ping response
-			var pingResult struct {
-				Host         string  `json:"host"`
-				ResponseTime int64   `json:"response_time"`
-				PacketLoss   float64 `json:"packet_loss"`
-				Available    bool    `json:"available"`
-			}
+	s.updateAPIState(req.PollerId, apiStatus)
 
-			if err := json.Unmarshal([]byte(service.Message), &pingResult); err != nil {
-				log.Printf("Failed to parse ICMP response for service %s: %v", service.ServiceName, err)
+	return &proto.PollerStatusResponse{Received: true}, nil
+}
 
-				continue
-			}
+// processBackfill records a report the poller queued while the core was
+// unreachable. Its services and node health go into the history at the time
+// the report was collected, while the current node state, the API state and
+// alerts are left to live reports.
+func (s *Server) processBackfill(req *proto.PollerStatusRequest, collected time.Time) error {
+	log.Printf("Processing backfilled status report for %s from %s",
+		req.PollerId, collected.Format(time.RFC3339))
 
-			// Add metric with the actual response time
-			if err := s.metrics.AddMetric(
-				req.PollerId,
-				time.Now(),
-				pingResult.ResponseTime,
-				service.ServiceName,
-			); err != nil {
-				log.Printf("Failed to add ICMP metric for %s: %v", service.ServiceName, err)
+	apiStatus := s.createNodeStatus(req, collected)
 
-				continue
-			}
+	s.processServices(req.PollerId, apiStatus, req.Services, collected)
 
-			log.Printf("Added ICMP metric for %s: time=%v response_time=%.2fms",
-				service.ServiceName,
-				time.Now().Format(time.RFC3339),
-				float64(pingResult.ResponseTime)/float64(time.Millisecond))
-		}
+	if err := s.db.AddNodeHistory(&db.NodeStatus{
+		NodeID:    req.PollerId,
+		IsHealthy: apiStatus.IsHealthy,
+		LastSeen:  collected,
+	}); err != nil {
+		return fmt.Errorf("failed to store node history: %w", err)
 	}
 
-	s.updateAPIState(req.PollerId, apiStatus)
+	s.processICMPMetrics(req.PollerId, req.Services, collected)
 
-	return &proto.PollerStatusResponse{Received: true}, nil
+	return nil
+}
+
+// processICMPMetrics adds the response times of ICMP checks to the metrics
+// manager at the time the report was collected.
+func (s *Server) processICMPMetrics(pollerID string, services []*proto.ServiceStatus, timestamp time.Time) {
+	if s.metrics == nil {
+		return
+	}
+
+	for _, service := range services {
+		if service.ServiceType != "icmp" {
+			continue
+		}
+
+		// Parse the ping response
+		var pingResult struct {
+			Host         string  `json:"host"`
+			ResponseTime int64   `json:"response_time"`
+			PacketLoss   float64 `json:"packet_loss"`
+			Available    bool    `json:"available"`
+		}
+
+		if err := json.Unmarshal([]byte(service.Message), &pingResult); err != nil {
+			log.Printf("Failed to parse ICMP response for service %s: %v", service.ServiceName, err)
+
+			continue
+		}
+
+		// Add metric with the actual response time
+		if err := s.metrics.AddMetric(
+			pollerID,
+			timestamp,
+			pingResult.ResponseTime,
+			service.ServiceName,
+		); err != nil {
+			log.Printf("Failed to add ICMP metric for %s: %v", service.ServiceName, err)
+
+			continue
+		}
+
+		log.Printf("Added ICMP metric for %s: time=%v response_time=%.2fms",
+			service.ServiceName,
+			timestamp.Format(time.RFC3339),
+			float64(pingResult.ResponseTime)/float64(time.Millisecond))
+	}
 }
 
 func getHostname() string {
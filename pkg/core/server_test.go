@@ -17,15 +17,20 @@
 package core
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/carverauto/serviceradar/pkg/core/alerts"
 	"github.com/carverauto/serviceradar/pkg/core/api"
+	"github.com/carverauto/serviceradar/pkg/db"
 	"github.com/carverauto/serviceradar/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func setupAlerter(cooldown time.Duration, setupFunc func(*alerts.WebhookAlerter)) *alerts.WebhookAlerter {
@@ -181,3 +186,194 @@ func TestProcessSweepData(t *testing.T) {
 		})
 	}
 }
+
+func TestReportStatusBackfill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := db.NewMockService(ctrl)
+	server := &Server{db: mockDB, config: &Config{KnownPollers: []string{"poller"}}}
+	collected := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	// Backfilled reports only go into the history, at the time they were collected.
+	mockDB.EXPECT().UpdateServiceStatus(gomock.Any()).DoAndReturn(func(status *db.ServiceStatus) error {
+		assert.Equal(t, "web", status.ServiceName)
+		assert.False(t, status.Available)
+		assert.Equal(t, collected, status.Timestamp)
+
+		return nil
+	})
+	mockDB.EXPECT().AddNodeHistory(&db.NodeStatus{NodeID: "poller", IsHealthy: false, LastSeen: collected}).Return(nil)
+
+	resp, err := server.ReportStatus(context.Background(), &proto.PollerStatusRequest{
+		PollerId:  "poller",
+		Timestamp: collected.Unix(),
+		Backfill:  true,
+		Services:  []*proto.ServiceStatus{{ServiceName: "web", ServiceType: "port", Message: `{}`}},
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.Received)
+}
+
+func TestServiceStateChangeAlerts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alerter := alerts.NewMockAlertService(ctrl)
+	server := &Server{webhooks: []alerts.AlertService{alerter}}
+
+	var sent []*alerts.WebhookAlert
+
+	alerter.EXPECT().Alert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, alert *alerts.WebhookAlert) error {
+		sent = append(sent, alert)
+
+		return nil
+	}).AnyTimes()
+
+	report := func(webUp, dbUp bool) []*alerts.WebhookAlert {
+		sent = nil
+
+		server.processServiceStateChanges(context.Background(), "poller", []api.ServiceStatus{
+			{Name: "web", Type: "port", Available: webUp},
+			{Name: "db", Type: "port", Available: dbUp, Message: `{"error": "connection refused"}`},
+		}, time.Now())
+
+		return sent
+	}
+
+	// A service that is down when first reported raises an alert.
+	require.Len(t, report(true, false), 1)
+	assert.Equal(t, alerts.ServiceDownTitle, sent[0].Title)
+	assert.Equal(t, "db", sent[0].ServiceName)
+	assert.Equal(t, alerts.Error, sent[0].Level)
+	assert.Equal(t, `{"error": "connection refused"}`, sent[0].Details["message"])
+
+	// Only state changes raise alerts.
+	assert.Empty(t, report(true, false))
+
+	require.Len(t, report(false, true), 2)
+	assert.Equal(t, alerts.ServiceDownTitle, sent[0].Title)
+	assert.Equal(t, "web", sent[0].ServiceName)
+	assert.Equal(t, alerts.ServiceRecoveredTitle, sent[1].Title)
+	assert.Equal(t, "db", sent[1].ServiceName)
+	assert.Equal(t, alerts.Info, sent[1].Level)
+}
+
+func TestServiceStateChangeAlertsPerAgent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alerter := alerts.NewMockAlertService(ctrl)
+	server := &Server{webhooks: []alerts.AlertService{alerter}}
+
+	var (
+		sent    []*alerts.WebhookAlert
+		sendErr error
+	)
+
+	alerter.EXPECT().Alert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, alert *alerts.WebhookAlert) error {
+		if sendErr != nil {
+			return sendErr
+		}
+
+		sent = append(sent, alert)
+
+		return nil
+	}).AnyTimes()
+
+	report := func(services ...api.ServiceStatus) []*alerts.WebhookAlert {
+		sent = nil
+
+		server.processServiceStateChanges(context.Background(), "poller", services, time.Now())
+
+		return sent
+	}
+
+	web := func(agent string, available bool) api.ServiceStatus {
+		return api.ServiceStatus{Name: "web", Type: "http", AgentName: agent, Available: available}
+	}
+
+	// Services with the same name on different agents are tracked apart.
+	require.Len(t, report(web("agent-1", true), web("agent-2", false)), 1)
+	assert.Equal(t, "agent-2", sent[0].AgentName)
+	assert.Equal(t, "agent-2", sent[0].Details["agent"])
+
+	require.Len(t, report(web("agent-1", false), web("agent-2", false)), 1)
+	assert.Equal(t, "agent-1", sent[0].AgentName)
+
+	// A recovery that could not be sent is sent with the next report.
+	sendErr = alerts.ErrWebhookCooldown
+
+	assert.Empty(t, report(web("agent-1", true), web("agent-2", false)))
+
+	sendErr = nil
+
+	require.Len(t, report(web("agent-1", true), web("agent-2", false)), 1)
+	assert.Equal(t, alerts.ServiceRecoveredTitle, sent[0].Title)
+	assert.Equal(t, "agent-1", sent[0].AgentName)
+
+	// Services that are no longer reported are forgotten.
+	assert.Empty(t, report(web("agent-1", true)))
+	assert.Len(t, server.serviceStates, 1)
+
+	require.Len(t, report(web("agent-1", true), web("agent-2", false)), 1)
+	assert.Equal(t, alerts.ServiceDownTitle, sent[0].Title)
+	assert.Equal(t, "agent-2", sent[0].AgentName)
+}
+
+func TestWebhookAlerter_ServiceDownUntilRecovered(t *testing.T) {
+	var requests int
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer webhook.Close()
+
+	alerter := alerts.NewWebhookAlerter(alerts.WebhookConfig{Enabled: true, URL: webhook.URL})
+	down := func(service string) *alerts.WebhookAlert {
+		return &alerts.WebhookAlert{Title: alerts.ServiceDownTitle, NodeID: "test-node", ServiceName: service}
+	}
+
+	ctx := context.Background()
+
+	require.NoError(t, alerter.Alert(ctx, down("service-1")))
+	require.NoError(t, alerter.Alert(ctx, down("service-1")))
+	require.NoError(t, alerter.Alert(ctx, down("service-2")))
+	assert.Equal(t, 2, requests, "a service that is already down is not alerted again")
+
+	alerter.MarkServiceAsRecovered("test-node", "", "service-1")
+
+	require.NoError(t, alerter.Alert(ctx, down("service-1")))
+	assert.Equal(t, 3, requests)
+}
+
+func TestWebhookAlerter_ServiceDownHeldBackByCooldown(t *testing.T) {
+	var requests int
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer webhook.Close()
+
+	alerter := alerts.NewWebhookAlerter(alerts.WebhookConfig{Enabled: true, URL: webhook.URL, Cooldown: time.Hour})
+	down := &alerts.WebhookAlert{Title: alerts.ServiceDownTitle, NodeID: "test-node", ServiceName: "web"}
+	key := alerts.AlertKey{NodeID: "test-node", Title: alerts.ServiceDownTitle, ServiceName: "web"}
+	ctx := context.Background()
+
+	// Hold the alert back as if it had been sent a moment ago.
+	alerter.LastAlertTimes[key] = time.Now()
+
+	require.ErrorIs(t, alerter.Alert(ctx, down), alerts.ErrWebhookCooldown)
+	assert.Equal(t, 0, requests)
+	assert.False(t, alerter.ServiceAlertStates[key], "an alert held back by the cooldown does not mark the service as down")
+
+	// Once the cooldown is over the alert is sent.
+	alerter.LastAlertTimes[key] = time.Now().Add(-2 * time.Hour)
+
+	require.NoError(t, alerter.Alert(ctx, down))
+	assert.Equal(t, 1, requests)
+}
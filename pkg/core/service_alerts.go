@@ -0,0 +1,203 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package core pkg/core/service_alerts.go
+package core
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/carverauto/serviceradar/pkg/core/alerts"
+	"github.com/carverauto/serviceradar/pkg/core/api"
+)
+
+// serviceKey identifies a service reported by an agent of a poller.
+type serviceKey struct {
+	nodeID      string
+	agentName   string
+	serviceName string
+}
+
+// serviceStateChange is a service whose availability changed.
+type serviceStateChange struct {
+	service api.ServiceStatus
+	down    bool
+}
+
+// processServiceStateChanges compares every reported service with its
+// previous state and sends a "Service Down" alert when it becomes unavailable
+// and a "Service Recovered" alert when it is available again. A service that
+// is unavailable the first time it is reported is treated as having gone down.
+// A change is only recorded once its alert is sent, or skipped as a duplicate,
+// so an alert that fails or is held back by a cooldown is retried with the
+// next report.
+func (s *Server) processServiceStateChanges(
+	ctx context.Context, nodeID string, services []api.ServiceStatus, timestamp time.Time) {
+	changes, removed := s.serviceStateChanges(nodeID, services)
+
+	// Services that are no longer reported may come back without ever being
+	// reported as recovered, so forget that they were down.
+	for _, key := range removed {
+		s.markServiceAsRecovered(key)
+	}
+
+	for i := range changes {
+		change := &changes[i]
+
+		var err error
+
+		if change.down {
+			err = s.handleServiceDown(ctx, nodeID, &change.service, timestamp)
+		} else {
+			err = s.handleServiceRecovery(ctx, nodeID, &change.service, timestamp)
+		}
+
+		if err != nil {
+			log.Printf("Failed to send alert for service %s on node %s: %v", change.service.Name, nodeID, err)
+
+			continue
+		}
+
+		s.setServiceState(nodeID, &change.service)
+	}
+}
+
+// serviceStateChanges returns the services whose availability changed since
+// they were last recorded, without recording the change. It records services
+// that are available the first time they are reported and drops the services
+// of the node that are missing from the report, returning the ones that were
+// down.
+func (s *Server) serviceStateChanges(
+	nodeID string, services []api.ServiceStatus) (changes []serviceStateChange, removed []serviceKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.serviceStates == nil {
+		s.serviceStates = make(map[serviceKey]bool)
+	}
+
+	reported := make(map[serviceKey]struct{}, len(services))
+
+	for i := range services {
+		svc := &services[i]
+		key := newServiceKey(nodeID, svc)
+		reported[key] = struct{}{}
+
+		wasAvailable, known := s.serviceStates[key]
+
+		switch {
+		case !known && svc.Available:
+			s.serviceStates[key] = true
+		case !svc.Available && (!known || wasAvailable):
+			changes = append(changes, serviceStateChange{service: *svc, down: true})
+		case svc.Available && !wasAvailable:
+			changes = append(changes, serviceStateChange{service: *svc})
+		}
+	}
+
+	for key, available := range s.serviceStates {
+		if key.nodeID != nodeID {
+			continue
+		}
+
+		if _, ok := reported[key]; ok {
+			continue
+		}
+
+		delete(s.serviceStates, key)
+
+		if !available {
+			removed = append(removed, key)
+		}
+	}
+
+	return changes, removed
+}
+
+// setServiceState records the availability of a service once its change has
+// been alerted.
+func (s *Server) setServiceState(nodeID string, svc *api.ServiceStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.serviceStates[newServiceKey(nodeID, svc)] = svc.Available
+}
+
+func newServiceKey(nodeID string, svc *api.ServiceStatus) serviceKey {
+	return serviceKey{nodeID: nodeID, agentName: svc.AgentName, serviceName: svc.Name}
+}
+
+// markServiceAsRecovered resets the "down" state of the service in the
+// alerters so its next outage is reported.
+func (s *Server) markServiceAsRecovered(key serviceKey) {
+	for _, webhook := range s.webhooks {
+		if alerter, ok := webhook.(*alerts.WebhookAlerter); ok {
+			alerter.MarkServiceAsRecovered(key.nodeID, key.agentName, key.serviceName)
+		}
+	}
+}
+
+func (s *Server) handleServiceDown(ctx context.Context, nodeID string, svc *api.ServiceStatus, timestamp time.Time) error {
+	alert := &alerts.WebhookAlert{
+		Level:       alerts.Error,
+		Title:       alerts.ServiceDownTitle,
+		Message:     fmt.Sprintf("Service '%s' on node '%s' is down", svc.Name, nodeID),
+		NodeID:      nodeID,
+		ServiceName: svc.Name,
+		AgentName:   svc.AgentName,
+		Timestamp:   timestamp.UTC().Format(time.RFC3339),
+		Details:     serviceAlertDetails(svc),
+	}
+
+	return s.sendAlert(ctx, alert)
+}
+
+func (s *Server) handleServiceRecovery(ctx context.Context, nodeID string, svc *api.ServiceStatus, timestamp time.Time) error {
+	s.markServiceAsRecovered(newServiceKey(nodeID, svc))
+
+	alert := &alerts.WebhookAlert{
+		Level:       alerts.Info,
+		Title:       alerts.ServiceRecoveredTitle,
+		Message:     fmt.Sprintf("Service '%s' on node '%s' has recovered", svc.Name, nodeID),
+		NodeID:      nodeID,
+		ServiceName: svc.Name,
+		AgentName:   svc.AgentName,
+		Timestamp:   timestamp.UTC().Format(time.RFC3339),
+		Details:     serviceAlertDetails(svc),
+	}
+
+	return s.sendAlert(ctx, alert)
+}
+
+func serviceAlertDetails(svc *api.ServiceStatus) map[string]any {
+	details := map[string]any{
+		"hostname":     getHostname(),
+		"service_type": svc.Type,
+	}
+
+	if svc.AgentName != "" {
+		details["agent"] = svc.AgentName
+	}
+
+	if svc.Message != "" {
+		details["message"] = svc.Message
+	}
+
+	return details
+}
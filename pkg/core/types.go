@@ -62,6 +62,7 @@ type Server struct {
 	metrics        metrics.MetricCollector
 	snmpManager    snmp.SNMPManager
 	config         *Config
+	serviceStates  map[serviceKey]bool // availability of every reported service, guarded by mu
 }
 
 // OIDStatusData represents the structure of OID status data.
@@ -72,6 +73,38 @@ type OIDStatusData struct {
 	LastError  string      `json:"last_error,omitempty"`
 }
 
+// HostMetricsData represents the resource usage reported by the agent host checker.
+type HostMetricsData struct {
+	CPU struct {
+		UsagePercent float64 `json:"usage_percent"`
+	} `json:"cpu"`
+	Memory struct {
+		AvailableBytes uint64  `json:"available_bytes"`
+		UsedPercent    float64 `json:"used_percent"`
+	} `json:"memory"`
+	Load struct {
+		Load1  float64 `json:"load1"`
+		Load5  float64 `json:"load5"`
+		Load15 float64 `json:"load15"`
+	} `json:"load"`
+	Disks []struct {
+		MountPoint  string  `json:"mount_point"`
+		FreeBytes   uint64  `json:"free_bytes"`
+		UsedPercent float64 `json:"used_percent"`
+	} `json:"disks"`
+	Error string `json:"error,omitempty"`
+}
+
+// PrometheusMetricsData represents the series forwarded by the agent Prometheus checker.
+type PrometheusMetricsData struct {
+	URL    string `json:"url"`
+	Series []struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels"`
+		Value  float64           `json:"value"`
+	} `json:"series"`
+}
+
 // ServiceStatus represents the status of a monitored service.
 type ServiceStatus struct {
 	NodeID      string
@@ -210,12 +210,12 @@ func (db *DB) initSchema() error {
 	return err
 }
 
-func (db *DB) UpdateNodeStatus(status *NodeStatus) error {
+func (db *DB) UpdateNodeStatus(status *NodeStatus) (err error) {
 	tx, err := db.Begin()
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	defer rollbackOnError(tx, err)
+	defer func() { rollbackOnError(tx, err) }()
 
 	err = db.updateExistingNode(tx, status)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -284,6 +284,25 @@ func (*DB) addNodeHistory(tx Transaction, status *NodeStatus) error {
 	return nil
 }
 
+// AddNodeHistory records a node status in the history without changing the
+// node's current state, for reports that arrive after newer ones.
+func (db *DB) AddNodeHistory(status *NodeStatus) (err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { rollbackOnError(tx, err) }()
+
+	err = db.addNodeHistory(tx, status)
+	if err != nil {
+		return fmt.Errorf("failed to add node history: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// rollbackOnError rolls the transaction back when err is set. Defer it in a
+// closure over a named error result, so it sees the error being returned.
 func rollbackOnError(tx Transaction, err error) {
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
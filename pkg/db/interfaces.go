@@ -73,6 +73,7 @@ type Service interface {
 	// Node operations.
 
 	UpdateNodeStatus(status *NodeStatus) error
+	AddNodeHistory(status *NodeStatus) error
 	GetNodeStatus(nodeID string) (*NodeStatus, error)
 	GetNodeHistory(nodeID string) ([]NodeStatus, error)
 	GetNodeHistoryPoints(nodeID string, limit int) ([]NodeHistoryPoint, error)
@@ -331,6 +331,20 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// AddNodeHistory mocks base method.
+func (m *MockService) AddNodeHistory(status *NodeStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNodeHistory", status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddNodeHistory indicates an expected call of AddNodeHistory.
+func (mr *MockServiceMockRecorder) AddNodeHistory(status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNodeHistory", reflect.TypeOf((*MockService)(nil).AddNodeHistory), status)
+}
+
 // Begin mocks base method.
 func (m *MockService) Begin() (Transaction, error) {
 	m.ctrl.T.Helper()
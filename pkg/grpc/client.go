@@ -24,8 +24,10 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 )
 
 // ClientConfig holds configuration for the gRPC client.
@@ -146,6 +148,11 @@ func RetryInterceptor(maxRetries int) grpc.UnaryClientInterceptor {
 				return nil
 			}
 
+			// A method the server does not implement will not appear on a retry.
+			if status.Code(err) == codes.Unimplemented {
+				return err
+			}
+
 			lastErr = err
 
 			if attempt == maxRetries-1 {
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestRetryInterceptorUnimplemented tests that a method the server does not
+// implement is not retried.
+func TestRetryInterceptorUnimplemented(t *testing.T) {
+	calls := 0
+
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		calls++
+
+		return status.Error(codes.Unimplemented, "unknown method")
+	}
+
+	err := RetryInterceptor(3)(context.Background(), "/test.Service/Method", nil, nil, nil, invoker)
+
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,279 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package poller pkg/poller/backlog.go
+package poller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/carverauto/serviceradar/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+const (
+	backlogSuffix    = ".pb"
+	backlogDirPerms  = 0o750
+	backlogFilePerms = 0o600
+)
+
+// backlog is a durable queue of status reports that could not be sent to the
+// core. Every report is kept in its own file named after the time it was
+// queued, so listing the directory gives the replay order.
+type backlog struct {
+	dir     string
+	maxSize int64
+	maxAge  time.Duration
+	mu      sync.Mutex
+	seq     int
+}
+
+// backlogEntry is a queued report file.
+type backlogEntry struct {
+	path   string
+	queued time.Time
+	size   int64
+}
+
+func openBacklog(cfg *BacklogConfig) (*backlog, error) {
+	if err := os.MkdirAll(cfg.Dir, backlogDirPerms); err != nil {
+		return nil, fmt.Errorf("failed to create backlog directory: %w", err)
+	}
+
+	b := &backlog{
+		dir:     cfg.Dir,
+		maxSize: cfg.MaxSize,
+		maxAge:  time.Duration(cfg.MaxAge),
+	}
+
+	entries, err := b.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) > 0 {
+		log.Printf("Found %d queued reports in %s", len(entries), cfg.Dir)
+	}
+
+	return b, nil
+}
+
+// push queues a report and then drops the oldest reports that no longer fit
+// the size and age bounds.
+func (b *backlog) push(req *proto.PollerStatusRequest) error {
+	data, err := protobuf.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.seq++
+
+	name := fmt.Sprintf("%020d-%06d%s", now.UnixNano(), b.seq, backlogSuffix)
+	tmp := filepath.Join(b.dir, "."+name)
+
+	if err := os.WriteFile(tmp, data, backlogFilePerms); err != nil {
+		return fmt.Errorf("failed to write queued report: %w", err)
+	}
+
+	if err := os.Rename(tmp, filepath.Join(b.dir, name)); err != nil {
+		_ = os.Remove(tmp)
+
+		return fmt.Errorf("failed to write queued report: %w", err)
+	}
+
+	return b.trim(now)
+}
+
+// trim removes reports older than maxAge and then the oldest reports until
+// the queue fits in maxSize. The caller must hold b.mu.
+func (b *backlog) trim(now time.Time) error {
+	entries, err := b.entries()
+	if err != nil {
+		return err
+	}
+
+	var total int64
+
+	for _, entry := range entries {
+		total += entry.size
+	}
+
+	dropped := 0
+
+	for _, entry := range entries {
+		if now.Sub(entry.queued) <= b.maxAge && total <= b.maxSize {
+			break
+		}
+
+		if err := os.Remove(entry.path); err != nil {
+			return fmt.Errorf("failed to drop queued report: %w", err)
+		}
+
+		total -= entry.size
+		dropped++
+	}
+
+	if dropped > 0 {
+		log.Printf("Dropped %d queued reports that exceeded the backlog limits", dropped)
+	}
+
+	return nil
+}
+
+// replay sends the queued reports in order, marked as backfill, and removes
+// each one once the core has accepted it. It stops at the first report that
+// could not be delivered because the core is unreachable; reports the core
+// rejects are dropped.
+func (b *backlog) replay(ctx context.Context, send func(context.Context, *proto.PollerStatusRequest) error) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.trim(time.Now()); err != nil {
+		return err
+	}
+
+	entries, err := b.entries()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	log.Printf("Replaying %d queued reports to core", len(entries))
+
+	for _, entry := range entries {
+		req, err := readQueuedReport(entry.path)
+		if err != nil {
+			log.Printf("Dropping unreadable queued report %s: %v", entry.path, err)
+		} else if err := send(ctx, markBackfill(req)); err != nil {
+			if coreUnreachable(err) {
+				return err
+			}
+
+			log.Printf("Core rejected queued report from %v, dropping it: %v", time.Unix(req.Timestamp, 0), err)
+		}
+
+		if err := os.Remove(entry.path); err != nil {
+			return fmt.Errorf("failed to remove queued report: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// count returns the number of queued reports.
+func (b *backlog) count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entries, err := b.entries()
+	if err != nil {
+		return 0
+	}
+
+	return len(entries)
+}
+
+// entries lists the queued reports, oldest first. The caller must hold b.mu
+// once the backlog is shared.
+func (b *backlog) entries() ([]backlogEntry, error) {
+	files, err := os.ReadDir(b.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backlog directory: %w", err)
+	}
+
+	entries := make([]backlogEntry, 0, len(files))
+
+	for _, file := range files {
+		name := file.Name()
+		if strings.HasPrefix(name, ".") || !strings.HasSuffix(name, backlogSuffix) {
+			continue
+		}
+
+		stamp, _, _ := strings.Cut(name, "-")
+
+		nanos, err := strconv.ParseInt(stamp, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, backlogEntry{
+			path:   filepath.Join(b.dir, name),
+			queued: time.Unix(0, nanos),
+			size:   info.Size(),
+		})
+	}
+
+	// ReadDir sorts by name, and names sort by queue time.
+	return entries, nil
+}
+
+func markBackfill(req *proto.PollerStatusRequest) *proto.PollerStatusRequest {
+	req.Backfill = true
+
+	return req
+}
+
+func readQueuedReport(path string) (*proto.PollerStatusRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &proto.PollerStatusRequest{}
+	if err := protobuf.Unmarshal(data, req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// coreUnreachable reports whether a failed report is worth sending again
+// later, as opposed to one the core rejected.
+func coreUnreachable(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package poller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/carverauto/serviceradar/pkg/config"
+	"github.com/carverauto/serviceradar/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCore records the reports it accepts while it is up.
+type fakeCore struct {
+	down    bool
+	reports []*proto.PollerStatusRequest
+}
+
+func (f *fakeCore) ReportStatus(
+	_ context.Context, req *proto.PollerStatusRequest, _ ...grpc.CallOption) (*proto.PollerStatusResponse, error) {
+	if f.down {
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	}
+
+	f.reports = append(f.reports, req)
+
+	return &proto.PollerStatusResponse{Received: true}, nil
+}
+
+func newTestBacklog(t *testing.T, maxSize int64, maxAge time.Duration) *backlog {
+	t.Helper()
+
+	b, err := openBacklog(&BacklogConfig{Dir: t.TempDir(), MaxSize: maxSize, MaxAge: config.Duration(maxAge)})
+	require.NoError(t, err)
+
+	return b
+}
+
+func TestBacklogReplay(t *testing.T) {
+	b := newTestBacklog(t, defaultBacklogMaxSize, defaultBacklogMaxAge)
+
+	for i := range int64(3) {
+		require.NoError(t, b.push(&proto.PollerStatusRequest{PollerId: "poller", Timestamp: 1000 + i}))
+	}
+
+	require.Equal(t, 3, b.count())
+
+	// Delivery stops at the first report the core does not receive.
+	var sent []int64
+
+	err := b.replay(context.Background(), func(_ context.Context, req *proto.PollerStatusRequest) error {
+		if len(sent) == 1 {
+			return status.Error(codes.Unavailable, "connection refused")
+		}
+
+		sent = append(sent, req.Timestamp)
+
+		return nil
+	})
+	require.Error(t, err)
+	assert.Equal(t, []int64{1000}, sent)
+	assert.Equal(t, 2, b.count())
+
+	// A report the core rejects is dropped rather than retried forever.
+	var replayed []*proto.PollerStatusRequest
+
+	err = b.replay(context.Background(), func(_ context.Context, req *proto.PollerStatusRequest) error {
+		if req.Timestamp == 1001 {
+			return status.Error(codes.InvalidArgument, "bad report")
+		}
+
+		replayed = append(replayed, req)
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, replayed, 1)
+	assert.Equal(t, int64(1002), replayed[0].Timestamp)
+	assert.True(t, replayed[0].Backfill)
+	assert.Zero(t, b.count())
+}
+
+func TestBacklogLimits(t *testing.T) {
+	report := &proto.PollerStatusRequest{
+		PollerId: "poller",
+		Services: []*proto.ServiceStatus{{ServiceName: "web", Message: `{"response_time": 1}`}},
+	}
+
+	b := newTestBacklog(t, 1<<20, time.Hour)
+	require.NoError(t, b.push(report))
+
+	entries, err := b.entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// Only the newest reports that fit in max_size are kept.
+	b.maxSize = 2 * entries[0].size
+
+	for range 4 {
+		require.NoError(t, b.push(report))
+	}
+
+	assert.Equal(t, 2, b.count())
+
+	// Reports older than max_age are dropped.
+	require.NoError(t, b.trim(time.Now().Add(2*time.Hour)))
+	assert.Zero(t, b.count())
+}
+
+func TestReportToCoreQueuesWhileCoreIsDown(t *testing.T) {
+	core := &fakeCore{down: true}
+	p := &Poller{
+		config:     Config{PollerID: "poller"},
+		coreClient: core,
+		backlog:    newTestBacklog(t, defaultBacklogMaxSize, defaultBacklogMaxAge),
+	}
+
+	ctx := context.Background()
+	web := []*proto.ServiceStatus{{ServiceName: "web", Available: true}}
+
+	require.Error(t, p.reportToCore(ctx, web))
+	require.Error(t, p.reportToCore(ctx, web))
+	assert.Equal(t, 2, p.backlog.count())
+
+	queued, err := p.backlog.entries()
+	require.NoError(t, err)
+
+	first, err := readQueuedReport(queued[0].path)
+	require.NoError(t, err)
+
+	core.down = false
+
+	require.NoError(t, p.reportToCore(ctx, web))
+	require.Len(t, core.reports, 3)
+	assert.Zero(t, p.backlog.count())
+
+	// Queued reports arrive first, as backfill with their original timestamps.
+	assert.True(t, core.reports[0].Backfill)
+	assert.Equal(t, first.Timestamp, core.reports[0].Timestamp)
+	assert.True(t, core.reports[1].Backfill)
+	assert.False(t, core.reports[2].Backfill)
+	assert.Equal(t, "web", core.reports[2].Services[0].ServiceName)
+}
@@ -31,7 +31,10 @@ var (
 )
 
 const (
-	pollDefaultInterval = 30 * time.Second
+	pollDefaultInterval     = 30 * time.Second
+	defaultAgentConcurrency = 10
+	defaultBacklogMaxSize   = 64 << 20 // 64 MiB
+	defaultBacklogMaxAge    = 24 * time.Hour
 )
 
 // AgentConfig represents configuration for a single agent.
@@ -41,12 +44,42 @@ type AgentConfig struct {
 	Security models.SecurityConfig `json:"security"` // Per-agent security config
 }
 
-// Check represents a service check configuration.
+// Check represents a service check configuration. Interval defaults to the
+// poll interval and Retries is the number of extra attempts made before the
+// check is reported as failed.
 type Check struct {
-	Type    string `json:"service_type"`
-	Name    string `json:"service_name"`
-	Details string `json:"details,omitempty"`
-	Port    int32  `json:"port,omitempty"`
+	Type       string          `json:"service_type"`
+	Name       string          `json:"service_name"`
+	Details    string          `json:"details,omitempty"`
+	Port       int32           `json:"port,omitempty"`
+	Interval   config.Duration `json:"interval,omitempty"`
+	Timeout    config.Duration `json:"timeout,omitempty"`
+	Retries    int             `json:"retries,omitempty"`
+	RetryDelay config.Duration `json:"retry_delay,omitempty"`
+}
+
+func (c *Check) interval(pollInterval time.Duration) time.Duration {
+	if c.Interval > 0 {
+		return time.Duration(c.Interval)
+	}
+
+	return pollInterval
+}
+
+func (c *Check) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return time.Duration(c.Timeout)
+	}
+
+	return defaultCheckTimeout
+}
+
+func (c *Check) retryDelay() time.Duration {
+	if c.RetryDelay > 0 {
+		return time.Duration(c.RetryDelay)
+	}
+
+	return defaultRetryDelay
 }
 
 // Config represents poller configuration.
@@ -58,12 +91,37 @@ type Config struct {
 	PollInterval config.Duration        `json:"poll_interval"`
 	PollerID     string                 `json:"poller_id"`
 	Security     *models.SecurityConfig `json:"security"`
+	// CoreAddresses lists the cores in order of preference. Reports go to
+	// the first healthy one, and CoreAddress is used when the list is empty.
+	CoreAddresses []string `json:"core_addresses,omitempty"`
+	// AgentConcurrency is how many agents are polled at the same time.
+	AgentConcurrency int `json:"agent_concurrency,omitempty"`
+	// AgentTimeout bounds polling a single agent. By default it is long
+	// enough for every check to use up its retries.
+	AgentTimeout config.Duration `json:"agent_timeout,omitempty"`
+	// StatusAddr is where the poller serves its status, liveness and
+	// readiness over HTTP. The endpoint is disabled when it is empty.
+	StatusAddr string `json:"status_addr,omitempty"`
+	// Backlog keeps reports on disk while the core is unreachable.
+	Backlog BacklogConfig `json:"backlog"`
+}
+
+// BacklogConfig bounds the on-disk queue of reports that could not be sent
+// to the core. The queue is disabled when Dir is empty.
+type BacklogConfig struct {
+	Dir     string          `json:"dir"`
+	MaxSize int64           `json:"max_size,omitempty"` // in bytes
+	MaxAge  config.Duration `json:"max_age,omitempty"`
 }
 
 // Validate implements config.Validator interface.
 func (c *Config) Validate() error {
-	if c.CoreAddress == "" {
-		return errCoreAddressRequired
+	if len(c.CoreAddresses) == 0 {
+		if c.CoreAddress == "" {
+			return errCoreAddressRequired
+		}
+
+		c.CoreAddresses = []string{c.CoreAddress}
 	}
 
 	if c.PollerID == "" {
@@ -80,5 +138,17 @@ func (c *Config) Validate() error {
 		c.PollInterval = config.Duration(pollDefaultInterval)
 	}
 
+	if c.AgentConcurrency <= 0 {
+		c.AgentConcurrency = defaultAgentConcurrency
+	}
+
+	if c.Backlog.MaxSize <= 0 {
+		c.Backlog.MaxSize = defaultBacklogMaxSize
+	}
+
+	if c.Backlog.MaxAge <= 0 {
+		c.Backlog.MaxAge = config.Duration(defaultBacklogMaxAge)
+	}
+
 	return nil
 }
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package poller pkg/poller/core.go
+package poller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/carverauto/serviceradar/pkg/grpc"
+	"github.com/carverauto/serviceradar/proto"
+)
+
+const coreHealthTimeout = 5 * time.Second
+
+// selectCore points the poller at the first healthy core in the configured
+// order before a report is sent. A poller that failed over to a later core
+// fails back as soon as an earlier one is healthy again. When no core is
+// healthy the current connection is kept, so the report fails and is queued.
+// Connections to standby cores are kept open for the next check.
+func (p *Poller) selectCore(ctx context.Context) {
+	addresses := p.config.CoreAddresses
+	if len(addresses) < 2 { //nolint:mnd // there is nothing to fail over to
+		return
+	}
+
+	p.mu.RLock()
+	current := p.coreIndex
+	p.mu.RUnlock()
+
+	for i, address := range addresses {
+		client, err := p.coreClientAt(ctx, i)
+		if err != nil {
+			log.Printf("Failed to connect to core %s: %v", address, err)
+
+			continue
+		}
+
+		if !p.coreHealthy(ctx, client) {
+			log.Printf("Core %s is not healthy", address)
+
+			continue
+		}
+
+		if i == current {
+			return
+		}
+
+		if i < current {
+			log.Printf("Failing back from core %s to %s", addresses[current], address)
+		} else {
+			log.Printf("Failing over from core %s to %s", addresses[current], address)
+		}
+
+		p.setCore(i, client)
+
+		return
+	}
+
+	log.Printf("No core is healthy, staying with %s", addresses[current])
+}
+
+// coreHealthy reports whether the core serves the poller service.
+func (*Poller) coreHealthy(ctx context.Context, client *grpc.Client) bool {
+	if client == nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, coreHealthTimeout)
+	defer cancel()
+
+	healthy, err := client.CheckHealth(ctx, proto.PollerService_ServiceDesc.ServiceName)
+
+	return err == nil && healthy
+}
+
+func (p *Poller) newCoreClient(ctx context.Context, address string) (*grpc.Client, error) {
+	clientCfg := grpc.ClientConfig{
+		Address:    address,
+		MaxRetries: grpcRetries,
+	}
+
+	if p.config.Security != nil {
+		provider, err := grpc.NewSecurityProvider(ctx, p.config.Security)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create security provider: %w", err)
+		}
+
+		clientCfg.SecurityProvider = provider
+	}
+
+	log.Printf("Connecting to core service at %s", address)
+
+	client, err := grpc.NewClient(ctx, clientCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create core client: %w", err)
+	}
+
+	return client, nil
+}
+
+// coreClientAt returns the connection to the core at the given index,
+// connecting on first use.
+func (p *Poller) coreClientAt(ctx context.Context, index int) (*grpc.Client, error) {
+	p.mu.RLock()
+	client := p.coreClients[index]
+	p.mu.RUnlock()
+
+	if client != nil {
+		return client, nil
+	}
+
+	client, err := p.newCoreClient(ctx, p.config.CoreAddresses[index])
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		_ = client.Close()
+
+		return nil, errPollerStopped
+	default:
+	}
+
+	p.coreClients[index] = client
+
+	return client, nil
+}
+
+// setCore switches reports to the core at the given index.
+func (p *Poller) setCore(index int, client *grpc.Client) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.coreIndex = index
+	p.grpcClient = client
+	p.coreClient = proto.NewPollerServiceClient(client.GetConnection())
+}
+
+// closeCoreClients closes the connections to every core. The caller must
+// hold p.mu.
+func (p *Poller) closeCoreClients() error {
+	var errs []error
+
+	for i, client := range p.coreClients {
+		if client == nil {
+			continue
+		}
+
+		if err := client.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("error closing core client %s: %w", p.config.CoreAddresses[i], err))
+		}
+
+		p.coreClients[i] = nil
+	}
+
+	p.grpcClient = nil
+	p.coreClient = nil
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package poller
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/carverauto/serviceradar/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// startCore serves the health service for the poller service and returns
+// its address.
+func startCore(t *testing.T) (string, *health.Server) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(proto.PollerService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	go func() { _ = server.Serve(ln) }()
+
+	t.Cleanup(server.Stop)
+
+	return ln.Addr().String(), healthServer
+}
+
+func TestSelectCore(t *testing.T) {
+	primary, primaryHealth := startCore(t)
+	secondary, _ := startCore(t)
+
+	p := &Poller{
+		config: Config{CoreAddresses: []string{primary, secondary}},
+		agents: make(map[string]*AgentConnection),
+		done:   make(chan struct{}),
+	}
+
+	ctx := context.Background()
+
+	require.NoError(t, p.connectToCore(ctx))
+
+	t.Cleanup(func() { _ = p.Close() })
+
+	p.selectCore(ctx)
+	assert.Equal(t, 0, p.coreIndex)
+
+	primaryHealth.SetServingStatus(proto.PollerService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	p.selectCore(ctx)
+	assert.Equal(t, 1, p.coreIndex, "fails over to the secondary")
+	assert.Equal(t, secondary, p.grpcClient.GetConnection().Target())
+
+	standby := p.grpcClient
+
+	p.selectCore(ctx)
+	assert.Equal(t, 1, p.coreIndex, "stays on the secondary while the primary is down")
+	assert.Same(t, standby, p.grpcClient, "the connection to the secondary is reused")
+
+	primaryHealth.SetServingStatus(proto.PollerService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	p.selectCore(ctx)
+	assert.Equal(t, 0, p.coreIndex, "fails back to the primary")
+	assert.Equal(t, primary, p.grpcClient.GetConnection().Target())
+	assert.Same(t, standby, p.coreClients[1], "the secondary stays connected as a standby")
+}
+
+func TestConnectToCoreFailsOver(t *testing.T) {
+	primary, primaryHealth := startCore(t)
+	secondary, _ := startCore(t)
+
+	primaryHealth.SetServingStatus(proto.PollerService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	p := &Poller{
+		config: Config{CoreAddresses: []string{primary, secondary}},
+		agents: make(map[string]*AgentConnection),
+		done:   make(chan struct{}),
+	}
+
+	ctx := context.Background()
+
+	require.NoError(t, p.connectToCore(ctx))
+
+	t.Cleanup(func() { _ = p.Close() })
+
+	assert.Equal(t, 1, p.coreIndex)
+	assert.Equal(t, secondary, p.grpcClient.GetConnection().Target())
+
+	// Reports after the poller stopped fail instead of using a closed client.
+	require.NoError(t, p.Stop(ctx))
+	require.ErrorIs(t, p.sendReport(ctx, &proto.PollerStatusRequest{}), errPollerStopped)
+}
+
+func TestValidateCoreAddresses(t *testing.T) {
+	cfg := Config{CoreAddress: "core:50052", PollerID: "poller", Agents: map[string]AgentConfig{"agent": {}}}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, []string{"core:50052"}, cfg.CoreAddresses)
+
+	cfg = Config{PollerID: "poller", Agents: map[string]AgentConfig{"agent": {}}}
+	require.ErrorIs(t, cfg.Validate(), errCoreAddressRequired)
+}
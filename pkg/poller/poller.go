@@ -22,18 +22,25 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/carverauto/serviceradar/pkg/grpc"
 	"github.com/carverauto/serviceradar/proto"
+	"google.golang.org/grpc/codes"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 const (
-	grpcRetries    = 3
-	defaultTimeout = 30 * time.Second
-	stopTimeout    = 10 * time.Second
+	grpcRetries         = 3
+	stopTimeout         = 10 * time.Second
+	defaultCheckTimeout = 10 * time.Second
+	defaultAgentTimeout = 30 * time.Second
+	defaultRetryDelay   = time.Second
+	rpcTimeoutMargin    = 5 * time.Second
 )
 
 var (
@@ -41,6 +48,13 @@ var (
 	ErrNoConnectionForAgent = fmt.Errorf("no connection found for agent")
 	ErrAgentUnhealthy       = fmt.Errorf("agent is unhealthy")
 	errClosing              = errors.New("error closing")
+	errMissingStatus        = errors.New("agent returned no status for check")
+	errAgentUnreachable     = errors.New("agent unreachable")
+	errAgentTimeout         = errors.New("agent timed out")
+	errPollerStopped        = errors.New("poller is stopped")
+	errPollStalled          = errors.New("poll cycle is stalled")
+	errNoPollCycle          = errors.New("no poll cycle has completed yet")
+	errReportQueued         = errors.New("queued the report for later")
 )
 
 // AgentConnection represents a connection to an agent.
@@ -48,18 +62,28 @@ type AgentConnection struct {
 	client       *grpc.Client // Updated to use grpc.Client
 	agentName    string
 	healthClient healthpb.HealthClient
+	// batchUnsupported is set once the agent rejects GetStatuses, so later
+	// cycles poll it check by check until it is reconnected.
+	batchUnsupported atomic.Bool
 }
 
 // Poller represents the monitoring poller.
 type Poller struct {
 	proto.UnimplementedPollerServiceServer
-	config     Config
-	coreClient proto.PollerServiceClient
-	grpcClient *grpc.Client // Updated to use grpc.Client
-	mu         sync.RWMutex
-	agents     map[string]*AgentConnection
-	done       chan struct{}
-	closeOnce  sync.Once
+	config      Config
+	coreClient  proto.PollerServiceClient
+	grpcClient  *grpc.Client   // Updated to use grpc.Client
+	coreIndex   int            // index of the current core in config.CoreAddresses
+	coreClients []*grpc.Client // by core index, nil until first used
+	mu          sync.RWMutex
+	agents      map[string]*AgentConnection
+	checks      map[string][]*checkState // by agent name, in config order
+	agentSlots  chan struct{}            // limits concurrent agent polls to agent_concurrency
+	backlog     *backlog                 // nil when disabled
+	state       pollState
+	statusSrv   *http.Server // nil unless status_addr is set
+	done        chan struct{}
+	closeOnce   sync.Once
 }
 
 // ServiceCheck manages a single service check operation.
@@ -73,9 +97,19 @@ func New(ctx context.Context, config *Config) (*Poller, error) {
 	p := &Poller{
 		config: *config,
 		agents: make(map[string]*AgentConnection),
+		checks: newCheckStates(config.Agents, time.Duration(config.PollInterval)),
 		done:   make(chan struct{}),
 	}
 
+	if config.Backlog.Dir != "" {
+		backlog, err := openBacklog(&config.Backlog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open backlog: %w", err)
+		}
+
+		p.backlog = backlog
+	}
+
 	// Connect to core service
 	if err := p.connectToCore(ctx); err != nil {
 		return nil, fmt.Errorf("failed to connect to core service: %w", err)
@@ -83,7 +117,8 @@ func New(ctx context.Context, config *Config) (*Poller, error) {
 
 	// Initialize agent connections
 	if err := p.initializeAgentConnections(ctx); err != nil {
-		_ = p.grpcClient.Close()
+		_ = p.Close()
+
 		return nil, fmt.Errorf("failed to initialize agent connections: %w", err)
 	}
 
@@ -118,13 +153,17 @@ func (d *Duration) UnmarshalJSON(b []byte) error {
 
 // Start implements the lifecycle.Service interface.
 func (p *Poller) Start(ctx context.Context) error {
-	interval := time.Duration(p.config.PollInterval)
+	interval := p.pollTick()
 
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	log.Printf("Starting poller with interval %v", interval)
 
+	if p.config.StatusAddr != "" {
+		p.startStatusServer()
+	}
+
 	// Initial poll
 	if err := p.poll(ctx); err != nil {
 		log.Printf("Error during initial poll: %v", err)
@@ -144,21 +183,21 @@ func (p *Poller) Start(ctx context.Context) error {
 
 // Stop implements the lifecycle.Service interface.
 func (p *Poller) Stop(ctx context.Context) error {
-	_, cancel := context.WithTimeout(ctx, stopTimeout)
+	stopCtx, cancel := context.WithTimeout(ctx, stopTimeout)
 	defer cancel()
 
 	p.closeOnce.Do(func() {
 		close(p.done) // Close channel first
 	})
 
+	p.stopStatusServer(stopCtx)
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	// Close core client first
-	if p.coreClient != nil {
-		if err := p.grpcClient.Close(); err != nil {
-			log.Printf("Error closing core client: %v", err)
-		}
+	// Close core clients first
+	if err := p.closeCoreClients(); err != nil {
+		log.Printf("Error closing core clients: %v", err)
 	}
 
 	// Wait for any active agent connections to finish
@@ -172,7 +211,6 @@ func (p *Poller) Stop(ctx context.Context) error {
 
 	// Clear the maps to prevent any lingering references
 	p.agents = make(map[string]*AgentConnection)
-	p.coreClient = nil
 
 	return nil
 }
@@ -186,11 +224,9 @@ func (p *Poller) Close() error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	// Close core client
-	if p.grpcClient != nil {
-		if err := p.grpcClient.Close(); err != nil {
-			errs = append(errs, fmt.Errorf("error closing core client: %w", err))
-		}
+	// Close core clients
+	if err := p.closeCoreClients(); err != nil {
+		errs = append(errs, err)
 	}
 
 	// Close all agent connections
@@ -211,54 +247,132 @@ func (p *Poller) Close() error {
 
 // AgentPoller manages polling operations for a single agent.
 type AgentPoller struct {
-	client  proto.AgentServiceClient
-	name    string
-	config  *AgentConfig
-	timeout time.Duration
+	client           proto.AgentServiceClient
+	name             string
+	config           *AgentConfig
+	batchUnsupported *atomic.Bool
 }
 
-func newAgentPoller(name string, config *AgentConfig, client proto.AgentServiceClient, timeout time.Duration) *AgentPoller {
+func newAgentPoller(name string, config *AgentConfig, client proto.AgentServiceClient) *AgentPoller {
 	return &AgentPoller{
-		name:    name,
-		config:  config,
-		client:  client,
-		timeout: timeout,
+		name:             name,
+		config:           config,
+		client:           client,
+		batchUnsupported: new(atomic.Bool),
 	}
 }
 
-// ExecuteChecks runs all configured service checks for the agent.
-func (ap *AgentPoller) ExecuteChecks(ctx context.Context) []*proto.ServiceStatus {
-	checkCtx, cancel := context.WithTimeout(ctx, ap.timeout)
+// ExecuteChecks runs the given checks of the agent and returns their statuses
+// in the same order. Checks that fail are retried as configured and are only
+// reported as failed once their retries are exhausted. An error means the
+// agent could not be reached at all.
+func (ap *AgentPoller) ExecuteChecks(ctx context.Context, checks []Check) ([]*proto.ServiceStatus, error) {
+	statuses, err := ap.executeBatch(ctx, checks)
+	if err != nil {
+		return nil, err
+	}
+
+	ap.retryFailedChecks(ctx, checks, statuses)
+
+	return statuses, nil
+}
+
+// executeBatch runs the checks in a single GetStatuses call. Agents that do
+// not support it yet are polled with one GetStatus call per check.
+func (ap *AgentPoller) executeBatch(ctx context.Context, checks []Check) ([]*proto.ServiceStatus, error) {
+	if ap.batchUnsupported.Load() {
+		return ap.executeChecksIndividually(ctx, checks), nil
+	}
+
+	requests := make([]*proto.StatusRequest, 0, len(checks))
+	deadline := time.Duration(0)
+
+	for _, check := range checks {
+		requests = append(requests, newStatusRequest(check))
+		deadline = max(deadline, check.timeout())
+	}
+
+	// The agent enforces the timeout of each check; the margin covers the round trip.
+	batchCtx, cancel := context.WithTimeout(ctx, deadline+rpcTimeoutMargin)
 	defer cancel()
 
-	results := make(chan *proto.ServiceStatus, len(ap.config.Checks))
-	statuses := make([]*proto.ServiceStatus, 0, len(ap.config.Checks))
+	resp, err := ap.client.GetStatuses(batchCtx, &proto.StatusesRequest{Requests: requests})
+	if status.Code(err) == codes.Unimplemented {
+		log.Printf("Agent %s does not support batch status requests, polling checks individually", ap.name)
+
+		ap.batchUnsupported.Store(true)
+
+		return ap.executeChecksIndividually(ctx, checks), nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statuses: %w", err)
+	}
+
+	statuses := make([]*proto.ServiceStatus, 0, len(checks))
+
+	for i, check := range checks {
+		switch {
+		case i >= len(resp.GetResponses()):
+			statuses = append(statuses, failedServiceStatus(check, errMissingStatus))
+		default:
+			statuses = append(statuses, newServiceStatus(check, resp.GetResponses()[i]))
+		}
+	}
+
+	return statuses, nil
+}
+
+func (ap *AgentPoller) executeChecksIndividually(ctx context.Context, checks []Check) []*proto.ServiceStatus {
+	statuses := make([]*proto.ServiceStatus, len(checks))
 
 	var wg sync.WaitGroup
 
-	for _, check := range ap.config.Checks {
+	for i, check := range checks {
 		wg.Add(1)
 
-		go func(check Check) {
+		go func() {
 			defer wg.Done()
 
-			svcCheck := newServiceCheck(ap.client, check)
-			results <- svcCheck.execute(checkCtx)
-		}(check)
+			statuses[i] = newServiceCheck(ap.client, check).execute(ctx)
+		}()
 	}
 
-	// Close results channel when all checks complete
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
+	wg.Wait()
+
+	return statuses
+}
+
+// retryFailedChecks retries every failed check with retries left, waiting
+// retry_delay before each attempt, and replaces its status with the result.
+func (ap *AgentPoller) retryFailedChecks(ctx context.Context, checks []Check, statuses []*proto.ServiceStatus) {
+	var wg sync.WaitGroup
+
+	for i, check := range checks {
+		if statuses[i].Available || check.Retries <= 0 {
+			continue
+		}
 
-	// Collect results
-	for result := range results {
-		statuses = append(statuses, result)
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for attempt := 1; attempt <= check.Retries && !statuses[i].Available; attempt++ {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(check.retryDelay()):
+				}
+
+				log.Printf("Retrying check %s on agent %s (attempt %d of %d)", check.Name, ap.name, attempt, check.Retries)
+
+				statuses[i] = newServiceCheck(ap.client, check).execute(ctx)
+			}
+		}()
 	}
 
-	return statuses
+	wg.Wait()
 }
 
 func newServiceCheck(client proto.AgentServiceClient, check Check) *ServiceCheck {
@@ -269,34 +383,66 @@ func newServiceCheck(client proto.AgentServiceClient, check Check) *ServiceCheck
 }
 
 func (sc *ServiceCheck) execute(ctx context.Context) *proto.ServiceStatus {
+	req := newStatusRequest(sc.check)
+
+	ctx, cancel := context.WithTimeout(ctx, sc.check.timeout())
+	defer cancel()
+
+	log.Printf("Sending StatusRequest for %s (type: %s)", req.GetServiceName(), req.GetServiceType())
+
+	resp, err := sc.client.GetStatus(ctx, req)
+	if err != nil {
+		return failedServiceStatus(sc.check, err)
+	}
+
+	return newServiceStatus(sc.check, resp)
+}
+
+func newStatusRequest(check Check) *proto.StatusRequest {
 	req := &proto.StatusRequest{
-		ServiceName: sc.check.Name,
-		ServiceType: sc.check.Type,
-		Details:     sc.check.Details,
+		ServiceName: check.Name,
+		ServiceType: check.Type,
+		Details:     check.Details,
+		Timeout:     int64(check.timeout()),
 	}
 
-	if sc.check.Type == "port" {
-		req.Port = sc.check.Port
+	if check.Type == "port" {
+		req.Port = check.Port
 	}
 
-	log.Printf("Sending StatusRequest: %+v", req)
+	return req
+}
 
-	status, err := sc.client.GetStatus(ctx, req)
-	if err != nil {
-		return &proto.ServiceStatus{
-			ServiceName: sc.check.Name,
-			Available:   false,
-			Message:     err.Error(),
-			ServiceType: sc.check.Type,
-		}
+func newServiceStatus(check Check, resp *proto.StatusResponse) *proto.ServiceStatus {
+	return &proto.ServiceStatus{
+		ServiceName:  check.Name,
+		Available:    resp.Available,
+		Message:      resp.Message,
+		ServiceType:  check.Type,
+		ResponseTime: resp.ResponseTime,
 	}
+}
+
+// unreachableServiceStatus reports a check of an agent that could not be polled.
+func unreachableServiceStatus(check Check, err error) *proto.ServiceStatus {
+	message, _ := json.Marshal(struct {
+		Error string `json:"error"`
+	}{Error: fmt.Sprintf("%v: %v", errAgentUnreachable, err)})
 
 	return &proto.ServiceStatus{
-		ServiceName:  sc.check.Name,
-		Available:    status.Available,
-		Message:      status.Message,
-		ServiceType:  sc.check.Type,
-		ResponseTime: status.ResponseTime,
+		ServiceName: check.Name,
+		Available:   false,
+		Message:     string(message),
+		ServiceType: check.Type,
+	}
+}
+
+func failedServiceStatus(check Check, err error) *proto.ServiceStatus {
+	return &proto.ServiceStatus{
+		ServiceName: check.Name,
+		Available:   false,
+		Message:     err.Error(),
+		ServiceType: check.Type,
 	}
 }
 
@@ -366,31 +512,18 @@ func (p *Poller) reconnectAgent(ctx context.Context, agentName string, config *A
 	return nil
 }
 
+// connectToCore connects to the first healthy core, or to the first core
+// when none is healthy yet.
 func (p *Poller) connectToCore(ctx context.Context) error {
-	// Use ClientConfig instead of ConnectionConfig
-	clientCfg := grpc.ClientConfig{
-		Address:    p.config.CoreAddress,
-		MaxRetries: grpcRetries,
-	}
-
-	if p.config.Security != nil {
-		provider, err := grpc.NewSecurityProvider(ctx, p.config.Security)
-		if err != nil {
-			return fmt.Errorf("failed to create security provider: %w", err)
-		}
+	p.coreClients = make([]*grpc.Client, len(p.config.CoreAddresses))
 
-		clientCfg.SecurityProvider = provider
-	}
-
-	log.Printf("Connecting to core service at %s", p.config.CoreAddress)
-
-	client, err := grpc.NewClient(ctx, clientCfg)
+	client, err := p.coreClientAt(ctx, 0)
 	if err != nil {
-		return fmt.Errorf("failed to create core client: %w", err)
+		return err
 	}
 
-	p.grpcClient = client
-	p.coreClient = proto.NewPollerServiceClient(client.GetConnection())
+	p.setCore(0, client)
+	p.selectCore(ctx)
 
 	return nil
 }
@@ -432,74 +565,253 @@ func (p *Poller) initializeAgentConnections(ctx context.Context) error {
 }
 
 // Poll execution methods.
+
+// poll runs the checks that are due, polling up to agent_concurrency agents
+// at a time, and reports the latest status of every check to the core. The
+// cycle waits at most one tick for the agents: one that is still being polled
+// is reported with its previous statuses and skipped until its poll ends.
 func (p *Poller) poll(ctx context.Context) error {
-	var allStatuses []*proto.ServiceStatus
+	now := time.Now()
+	tick := p.pollTick()
 
-	for agentName := range p.config.Agents {
-		agentConfig := p.config.Agents[agentName]
+	p.state.startCycle(now)
+	slack := tick / 2 //nolint:mnd // tolerate ticker jitter of up to half a tick
 
-		conn, err := p.getAgentConnection(agentName)
-		if err != nil {
-			if err = p.reconnectAgent(ctx, agentName, &agentConfig); err != nil {
-				log.Printf("Failed to reconnect to agent %s: %v", agentName, err)
-				continue
-			}
+	if p.agentSlots == nil {
+		p.agentSlots = make(chan struct{}, max(p.config.AgentConcurrency, 1))
+	}
+
+	slots := p.agentSlots
+
+	var wg sync.WaitGroup
 
-			conn, _ = p.getAgentConnection(agentName)
+	for agentName := range p.config.Agents {
+		if !p.state.startAgentPoll(agentName) {
+			log.Printf("Agent %s is still being polled, skipping it this cycle", agentName)
+
+			continue
 		}
 
-		// Check health before polling
-		healthy, err := conn.client.CheckHealth(ctx, "AgentService")
-		if err != nil || !healthy {
-			if err = p.reconnectAgent(ctx, agentName, &agentConfig); err != nil {
-				log.Printf("Agent %s unhealthy: %v", agentName, err)
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer p.state.endAgentPoll(agentName)
 
-				continue
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
 			}
+			defer func() { <-slots }()
+
+			p.pollDueChecks(ctx, agentName, now, slack)
+		}()
+	}
+
+	if !waitUntil(ctx, &wg, now.Add(tick)) {
+		log.Printf("Reporting before every agent finished polling")
+	}
+
+	var allStatuses []*proto.ServiceStatus
+
+	p.state.mu.RLock()
+
+	for agentName := range p.config.Agents {
+		allStatuses = append(allStatuses, latestStatuses(p.checks[agentName])...)
+	}
+
+	p.state.mu.RUnlock()
+
+	err := p.reportToCore(ctx, allStatuses)
+
+	p.state.endCycle(time.Now(), err)
+
+	return err
+}
+
+// waitUntil waits for wg until the deadline and reports whether it finished.
+func waitUntil(ctx context.Context, wg *sync.WaitGroup, deadline time.Time) bool {
+	done := make(chan struct{})
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// pollDueChecks runs the due checks of an agent within the agent deadline and
+// records their statuses. If the agent cannot be reached in time, all of its
+// checks are reported as unreachable.
+func (p *Poller) pollDueChecks(ctx context.Context, agentName string, now time.Time, slack time.Duration) {
+	states := p.checks[agentName]
+
+	due := dueChecks(states, now, slack)
+	if len(due) == 0 {
+		return
+	}
+
+	agentConfig := p.config.Agents[agentName]
+	timeout := p.agentTimeout(due)
+
+	agentCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	statuses, err := p.pollAgentChecks(agentCtx, agentName, &agentConfig, due)
+	if err == nil && errors.Is(agentCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w after %v", errAgentTimeout, timeout)
+	}
+
+	p.state.mu.Lock()
+	defer p.state.mu.Unlock()
+
+	p.state.recordAgentPoll(agentName, start, time.Since(start), err)
+
+	if err != nil {
+		log.Printf("Agent %s unreachable: %v", agentName, err)
+
+		for _, state := range states {
+			state.status = unreachableServiceStatus(state.check, err)
+			state.status.AgentName = agentName
+			state.lastRun = start
 		}
 
-		statuses, err := p.pollAgent(ctx, agentName, &agentConfig)
-		if err != nil {
-			log.Printf("Error polling agent %s: %v", agentName, err)
+		return
+	}
 
-			continue
+	for i, state := range due {
+		state.status = statuses[i]
+		state.status.AgentName = agentName
+		state.lastRun = start
+	}
+}
+
+// agentTimeout returns the deadline for polling an agent: agent_timeout when
+// set, otherwise long enough for every due check to use up its retries.
+func (p *Poller) agentTimeout(due []*checkState) time.Duration {
+	if p.config.AgentTimeout > 0 {
+		return time.Duration(p.config.AgentTimeout)
+	}
+
+	timeout := defaultAgentTimeout
+
+	for _, state := range due {
+		check := state.check
+		attempts := time.Duration(check.Retries + 1)
+
+		timeout = max(timeout, attempts*check.timeout()+time.Duration(check.Retries)*check.retryDelay()+rpcTimeoutMargin)
+	}
+
+	return timeout
+}
+
+// pollAgentChecks connects to the agent if needed, checks its health once,
+// reconnecting if it is unhealthy, and runs the due checks.
+func (p *Poller) pollAgentChecks(
+	ctx context.Context, agentName string, agentConfig *AgentConfig, due []*checkState) ([]*proto.ServiceStatus, error) {
+	agent, err := p.getAgentConnection(agentName)
+	if err != nil {
+		if err = p.reconnectAgent(ctx, agentName, agentConfig); err != nil {
+			return nil, fmt.Errorf("failed to reconnect: %w", err)
 		}
+	} else if err = p.ensureAgentHealth(ctx, agentName, agentConfig, agent); err != nil {
+		return nil, err
+	}
 
-		allStatuses = append(allStatuses, statuses...)
+	checks := make([]Check, 0, len(due))
+	for _, state := range due {
+		checks = append(checks, state.check)
 	}
 
-	return p.reportToCore(ctx, allStatuses)
+	return p.pollAgent(ctx, agentName, agentConfig, checks)
 }
 
+// pollAgent runs the checks on the agent's current connection.
 func (p *Poller) pollAgent(
 	ctx context.Context,
 	agentName string,
-	agentConfig *AgentConfig) ([]*proto.ServiceStatus, error) {
+	agentConfig *AgentConfig,
+	checks []Check) ([]*proto.ServiceStatus, error) {
 	agent, err := p.getAgentConnection(agentName)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := p.ensureAgentHealth(ctx, agentName, agentConfig, agent); err != nil {
-		return nil, err
-	}
-
 	client := proto.NewAgentServiceClient(agent.client.GetConnection())
-	poller := newAgentPoller(agentName, agentConfig, client, defaultTimeout)
-
-	statuses := poller.ExecuteChecks(ctx)
+	poller := newAgentPoller(agentName, agentConfig, client)
+	poller.batchUnsupported = &agent.batchUnsupported
 
-	return statuses, nil
+	return poller.ExecuteChecks(ctx, checks)
 }
 
+// reportToCore sends the statuses to the core. With a backlog, queued reports
+// are replayed first and the report is queued as well if the core cannot be
+// reached, so reports reach the core in the order they were collected.
 func (p *Poller) reportToCore(ctx context.Context, statuses []*proto.ServiceStatus) error {
-	_, err := p.coreClient.ReportStatus(ctx, &proto.PollerStatusRequest{
+	req := &proto.PollerStatusRequest{
 		Services:  statuses,
 		PollerId:  p.config.PollerID,
 		Timestamp: time.Now().Unix(),
-	})
+	}
 
-	if err != nil {
+	p.selectCore(ctx)
+
+	if p.backlog == nil {
+		return p.sendReport(ctx, req)
+	}
+
+	if err := p.backlog.replay(ctx, p.sendReport); err != nil {
+		if coreUnreachable(err) {
+			return p.queueReport(req, err)
+		}
+
+		log.Printf("Error replaying queued reports: %v", err)
+	}
+
+	if err := p.sendReport(ctx, req); err != nil {
+		if coreUnreachable(err) {
+			return p.queueReport(req, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// queueReport keeps a report that could not be sent to the core.
+func (p *Poller) queueReport(req *proto.PollerStatusRequest, sendErr error) error {
+	if err := p.backlog.push(req); err != nil {
+		return errors.Join(sendErr, err)
+	}
+
+	return fmt.Errorf("%w, %w", sendErr, errReportQueued)
+}
+
+func (p *Poller) sendReport(ctx context.Context, req *proto.PollerStatusRequest) error {
+	p.mu.RLock()
+	client := p.coreClient
+	p.mu.RUnlock()
+
+	if client == nil {
+		return errPollerStopped
+	}
+
+	if _, err := client.ReportStatus(ctx, req); err != nil {
 		return fmt.Errorf("failed to report status to core: %w", err)
 	}
 
@@ -0,0 +1,434 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package poller
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/carverauto/serviceradar/pkg/config"
+	"github.com/carverauto/serviceradar/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAgent answers status requests from a list of availabilities per
+// service, one per call; the last one repeats.
+type fakeAgent struct {
+	mu       sync.Mutex
+	results  map[string][]bool
+	calls    map[string]int
+	noBatch  bool
+	batches  int
+	timeouts map[string]int64
+	err      error
+	block    chan struct{} // when set, GetStatuses waits for it to close
+}
+
+func newFakeAgent(results map[string][]bool) *fakeAgent {
+	return &fakeAgent{results: results, calls: make(map[string]int), timeouts: make(map[string]int64)}
+}
+
+func (f *fakeAgent) GetStatus(_ context.Context, req *proto.StatusRequest, _ ...grpc.CallOption) (*proto.StatusResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	results := f.results[req.ServiceName]
+	call := min(f.calls[req.ServiceName], len(results)-1)
+	f.calls[req.ServiceName]++
+	f.timeouts[req.ServiceName] = req.Timeout
+
+	return &proto.StatusResponse{Available: results[call], Message: `{}`, ServiceName: req.ServiceName}, nil
+}
+
+func (f *fakeAgent) GetStatuses(
+	ctx context.Context, req *proto.StatusesRequest, opts ...grpc.CallOption) (*proto.StatusesResponse, error) {
+	f.mu.Lock()
+	f.batches++
+	f.mu.Unlock()
+
+	if f.block != nil {
+		select {
+		case <-f.block:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if f.noBatch {
+		return nil, status.Error(codes.Unimplemented, "unknown method GetStatuses")
+	}
+
+	resp := &proto.StatusesResponse{}
+
+	for _, r := range req.Requests {
+		result, err := f.GetStatus(ctx, r, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Responses = append(resp.Responses, result)
+	}
+
+	return resp, nil
+}
+
+func TestExecuteChecksRetries(t *testing.T) {
+	for _, noBatch := range []bool{false, true} {
+		agent := newFakeAgent(map[string][]bool{
+			"web":   {true},
+			"flaky": {false, false, true},
+			"down":  {false},
+		})
+		agent.noBatch = noBatch
+
+		checks := []Check{
+			{Name: "web", Type: "port"},
+			{Name: "flaky", Type: "port", Retries: 2, RetryDelay: config.Duration(time.Millisecond)},
+			{Name: "down", Type: "port", Retries: 1, RetryDelay: config.Duration(time.Millisecond), Timeout: config.Duration(time.Second)},
+		}
+
+		statuses, err := newAgentPoller("agent", &AgentConfig{}, agent).ExecuteChecks(context.Background(), checks)
+		require.NoError(t, err)
+		require.Len(t, statuses, 3)
+
+		assert.Equal(t, "web", statuses[0].ServiceName)
+		assert.True(t, statuses[0].Available)
+		assert.True(t, statuses[1].Available, "flaky succeeds on its last retry")
+		assert.False(t, statuses[2].Available, "down fails once its retries are exhausted")
+
+		assert.Equal(t, map[string]int{"web": 1, "flaky": 3, "down": 2}, agent.calls)
+		assert.Equal(t, int64(time.Second), agent.timeouts["down"])
+		assert.Equal(t, int64(defaultCheckTimeout), agent.timeouts["web"])
+	}
+}
+
+func TestExecuteChecksRemembersMissingBatchSupport(t *testing.T) {
+	agent := newFakeAgent(map[string][]bool{"web": {true}})
+	agent.noBatch = true
+
+	batchUnsupported := new(atomic.Bool)
+
+	for range 3 {
+		poller := newAgentPoller("agent", &AgentConfig{}, agent)
+		poller.batchUnsupported = batchUnsupported
+
+		statuses, err := poller.ExecuteChecks(context.Background(), []Check{{Name: "web"}})
+		require.NoError(t, err)
+		assert.True(t, statuses[0].Available)
+	}
+
+	assert.Equal(t, 1, agent.batches, "GetStatuses is only tried until the agent rejects it")
+	assert.Equal(t, 3, agent.calls["web"])
+}
+
+func TestExecuteChecksAgentDown(t *testing.T) {
+	agent := newFakeAgent(nil)
+	agent.err = status.Error(codes.Unavailable, "connection refused")
+
+	_, err := newAgentPoller("agent", &AgentConfig{}, agent).ExecuteChecks(context.Background(), []Check{{Name: "web"}})
+	require.Error(t, err)
+	assert.Empty(t, agent.calls, "checks of an unreachable agent are not retried")
+}
+
+func TestPollDueChecksUnreachable(t *testing.T) {
+	agents := map[string]AgentConfig{
+		"down": {Address: "127.0.0.1:1", Checks: []Check{{Name: "web", Type: "port"}, {Name: "sshd", Type: "process"}}},
+	}
+
+	p := &Poller{
+		config: Config{Agents: agents, PollInterval: config.Duration(time.Minute), AgentTimeout: config.Duration(time.Second)},
+		agents: make(map[string]*AgentConnection),
+		checks: newCheckStates(agents, time.Minute),
+		done:   make(chan struct{}),
+	}
+
+	t.Cleanup(func() { _ = p.Close() })
+
+	start := time.Now()
+	p.pollDueChecks(context.Background(), "down", start, time.Second)
+
+	assert.Less(t, time.Since(start), 5*time.Second, "the agent deadline bounds the poll")
+
+	statuses := latestStatuses(p.checks["down"])
+	require.Len(t, statuses, 2)
+
+	for _, s := range statuses {
+		assert.False(t, s.Available)
+		assert.Contains(t, s.Message, errAgentUnreachable.Error())
+		assert.Equal(t, "down", s.AgentName)
+	}
+
+	assert.Equal(t, "sshd", statuses[1].ServiceName)
+	assert.Equal(t, "process", statuses[1].ServiceType)
+}
+
+// agentServer serves a fakeAgent over gRPC.
+type agentServer struct {
+	proto.UnimplementedAgentServiceServer
+	agent *fakeAgent
+}
+
+func (a *agentServer) GetStatuses(ctx context.Context, req *proto.StatusesRequest) (*proto.StatusesResponse, error) {
+	return a.agent.GetStatuses(ctx, req)
+}
+
+// startAgentServer serves the fake agent over gRPC and returns its address.
+func startAgentServer(t *testing.T, agent *fakeAgent) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	proto.RegisterAgentServiceServer(server, &agentServer{agent: agent})
+
+	go func() { _ = server.Serve(ln) }()
+
+	t.Cleanup(server.Stop)
+
+	return ln.Addr().String()
+}
+
+func TestPollDoesNotWaitForSlowAgents(t *testing.T) {
+	slow := newFakeAgent(map[string][]bool{"db": {true}})
+	slow.block = make(chan struct{})
+	fast := newFakeAgent(map[string][]bool{"web": {true}})
+
+	agents := map[string]AgentConfig{
+		"slow": {Address: startAgentServer(t, slow), Checks: []Check{{Name: "db", Type: "postgres"}}},
+		"fast": {Address: startAgentServer(t, fast), Checks: []Check{{Name: "web", Type: "port"}}},
+	}
+
+	core := &fakeCore{}
+	p := &Poller{
+		config: Config{
+			Agents:           agents,
+			PollInterval:     config.Duration(200 * time.Millisecond),
+			AgentConcurrency: len(agents),
+		},
+		coreClient: core,
+		agents:     make(map[string]*AgentConnection),
+		checks:     newCheckStates(agents, 200*time.Millisecond),
+		done:       make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	t.Cleanup(func() {
+		cancel()
+		close(slow.block)
+		_ = p.Close()
+	})
+
+	for cycle := range 2 {
+		start := time.Now()
+
+		require.NoError(t, p.poll(ctx))
+		assert.Less(t, time.Since(start), time.Second, "the cycle ends with its tick")
+
+		require.Len(t, core.reports, cycle+1)
+		statuses := core.reports[cycle].Services
+		require.Len(t, statuses, 1, "the slow agent has no status yet")
+		assert.Equal(t, "web", statuses[0].ServiceName)
+		assert.True(t, statuses[0].Available)
+	}
+
+	slow.mu.Lock()
+	assert.Equal(t, 1, slow.batches, "an agent still being polled is skipped")
+	slow.mu.Unlock()
+}
+
+func TestCheckIntervalHoldsWhileAgentIsSlow(t *testing.T) {
+	slow := newFakeAgent(map[string][]bool{"db": {true}})
+	slow.block = make(chan struct{})
+	fast := newFakeAgent(map[string][]bool{"web": {true}})
+
+	interval := 100 * time.Millisecond
+	agents := map[string]AgentConfig{
+		"slow": {Address: startAgentServer(t, slow), Checks: []Check{{Name: "db", Type: "postgres"}}},
+		"fast": {
+			Address: startAgentServer(t, fast),
+			Checks:  []Check{{Name: "web", Type: "port", Interval: config.Duration(interval)}},
+		},
+	}
+
+	p := &Poller{
+		config: Config{
+			Agents:           agents,
+			PollInterval:     config.Duration(time.Minute),
+			AgentConcurrency: len(agents),
+		},
+		coreClient: &fakeCore{},
+		agents:     make(map[string]*AgentConnection),
+		checks:     newCheckStates(agents, time.Minute),
+		done:       make(chan struct{}),
+	}
+
+	t.Cleanup(func() {
+		close(slow.block)
+		_ = p.Close()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*interval+interval/2)
+	defer cancel()
+
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		_ = p.Start(ctx)
+	}()
+
+	<-stopped
+
+	fast.mu.Lock()
+	defer fast.mu.Unlock()
+
+	// The check runs right away and then on every interval.
+	assert.InDelta(t, 11, fast.calls["web"], 2)
+}
+
+func TestPollDueChecksChecksHealthOncePerCycle(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	var healthChecks atomic.Int32
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if info.FullMethod == healthpb.Health_Check_FullMethodName {
+				healthChecks.Add(1)
+			}
+
+			return handler(ctx, req)
+		}))
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("AgentService", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	proto.RegisterAgentServiceServer(server, &agentServer{agent: newFakeAgent(map[string][]bool{"web": {true}})})
+
+	go func() { _ = server.Serve(ln) }()
+
+	t.Cleanup(server.Stop)
+
+	agents := map[string]AgentConfig{
+		"edge": {Address: ln.Addr().String(), Checks: []Check{{Name: "web", Type: "port"}}},
+	}
+
+	p := &Poller{
+		config: Config{Agents: agents, PollInterval: config.Duration(time.Minute)},
+		agents: make(map[string]*AgentConnection),
+		checks: newCheckStates(agents, time.Minute),
+		done:   make(chan struct{}),
+	}
+
+	t.Cleanup(func() { _ = p.Close() })
+
+	// The first cycle connects; the second checks the existing connection.
+	now := time.Now()
+
+	for cycle := range 2 {
+		p.pollDueChecks(context.Background(), "edge", now.Add(time.Duration(cycle)*time.Hour), time.Second)
+
+		statuses := latestStatuses(p.checks["edge"])
+		require.Len(t, statuses, 1)
+		assert.True(t, statuses[0].Available, statuses[0].Message)
+	}
+
+	assert.Equal(t, int32(1), healthChecks.Load())
+}
+
+func TestAgentTimeout(t *testing.T) {
+	p := &Poller{}
+	due := []*checkState{
+		{check: Check{Name: "web"}},
+		{check: Check{Name: "slow", Timeout: config.Duration(20 * time.Second), Retries: 2, RetryDelay: config.Duration(time.Second)}},
+	}
+
+	assert.Equal(t, 20*time.Second*3+2*time.Second+rpcTimeoutMargin, p.agentTimeout(due))
+	assert.Equal(t, defaultAgentTimeout, p.agentTimeout(due[:1]))
+
+	p.config.AgentTimeout = config.Duration(time.Minute)
+	assert.Equal(t, time.Minute, p.agentTimeout(due))
+}
+
+func TestDueChecks(t *testing.T) {
+	p := &Poller{
+		config: Config{PollInterval: config.Duration(time.Minute)},
+		checks: newCheckStates(map[string]AgentConfig{
+			"agent": {Checks: []Check{
+				{Name: "web", Type: "port", Interval: config.Duration(10 * time.Second)},
+				{Name: "snmp", Type: "snmp", Interval: config.Duration(5 * time.Minute)},
+				{Name: "sshd", Type: "process"},
+			}},
+		}, time.Minute),
+	}
+
+	require.Equal(t, 10*time.Second, p.pollTick())
+
+	states := p.checks["agent"]
+	start := time.Now()
+	slack := p.pollTick() / 2
+
+	names := func(due []*checkState) []string {
+		var names []string
+		for _, state := range due {
+			names = append(names, state.check.Name)
+		}
+
+		return names
+	}
+
+	assert.Equal(t, []string{"web", "snmp", "sshd"}, names(dueChecks(states, start, slack)))
+	assert.Empty(t, dueChecks(states, start.Add(time.Second), slack))
+
+	// A tick that arrives slightly early still runs the check.
+	assert.Equal(t, []string{"web"}, names(dueChecks(states, start.Add(9900*time.Millisecond), slack)))
+	assert.Equal(t, []string{"web", "sshd"}, names(dueChecks(states, start.Add(time.Minute), slack)))
+	assert.Equal(t, []string{"web", "snmp", "sshd"}, names(dueChecks(states, start.Add(5*time.Minute), slack)))
+
+	// A late run keeps the check on its schedule.
+	assert.Equal(t, []string{"web"}, names(dueChecks(states, start.Add(5*time.Minute+14*time.Second), 0)))
+	assert.Equal(t, []string{"web"}, names(dueChecks(states, start.Add(5*time.Minute+20*time.Second), 0)))
+}
+
+func TestLatestStatuses(t *testing.T) {
+	states := []*checkState{
+		{check: Check{Name: "web"}, status: &proto.ServiceStatus{ServiceName: "web", Available: true}},
+		{check: Check{Name: "snmp"}},
+	}
+
+	statuses := latestStatuses(states)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "web", statuses[0].ServiceName)
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package poller
+
+import (
+	"time"
+
+	"github.com/carverauto/serviceradar/proto"
+)
+
+// checkState tracks when a configured check runs next and its latest status.
+// Once polling starts, status and lastRun are guarded by the poller's state.mu.
+type checkState struct {
+	check    Check
+	interval time.Duration
+	nextRun  time.Time
+	status   *proto.ServiceStatus
+	lastRun  time.Time
+}
+
+func newCheckStates(agents map[string]AgentConfig, pollInterval time.Duration) map[string][]*checkState {
+	states := make(map[string][]*checkState, len(agents))
+
+	for name, agent := range agents {
+		for _, check := range agent.Checks {
+			states[name] = append(states[name], &checkState{
+				check:    check,
+				interval: check.interval(pollInterval),
+			})
+		}
+	}
+
+	return states
+}
+
+// pollTick returns how often the poller wakes up: the poll interval, or the
+// shortest check interval if that is shorter.
+func (p *Poller) pollTick() time.Duration {
+	tick := time.Duration(p.config.PollInterval)
+
+	for _, states := range p.checks {
+		for _, state := range states {
+			tick = min(tick, state.interval)
+		}
+	}
+
+	return tick
+}
+
+// dueChecks returns the checks that are due at now, or within slack of it,
+// and schedules their next run one interval after the time they were due, so
+// a check that ran late keeps its schedule. A check that fell behind by more
+// than an interval starts a new schedule at now.
+func dueChecks(states []*checkState, now time.Time, slack time.Duration) []*checkState {
+	var due []*checkState
+
+	for _, state := range states {
+		if now.Add(slack).Before(state.nextRun) {
+			continue
+		}
+
+		state.nextRun = state.nextRun.Add(state.interval)
+		if !state.nextRun.After(now) {
+			state.nextRun = now.Add(state.interval)
+		}
+
+		due = append(due, state)
+	}
+
+	return due
+}
+
+// latestStatuses returns the latest status of every check that has one.
+func latestStatuses(states []*checkState) []*proto.ServiceStatus {
+	statuses := make([]*proto.ServiceStatus, 0, len(states))
+
+	for _, state := range states {
+		if state.status != nil {
+			statuses = append(statuses, state.status)
+		}
+	}
+
+	return statuses
+}
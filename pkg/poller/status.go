@@ -0,0 +1,325 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package poller pkg/poller/status.go
+package poller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	statusReadTimeout  = 10 * time.Second
+	statusWriteTimeout = 10 * time.Second
+	// cycleStallMargin is added to the tick a poll cycle waits for the agents
+	// before the poller is reported as not alive, to cover reporting to the
+	// core and replaying the backlog.
+	cycleStallMargin = 5 * time.Minute
+)
+
+// pollState is what the poller knows about its recent poll cycles, for the
+// status endpoint. mu also guards the status and lastRun of every checkState.
+type pollState struct {
+	mu         sync.RWMutex
+	agents     map[string]*agentPollState
+	polling    map[string]bool // agents whose poll is still running
+	cycleStart time.Time       // zero while no cycle is running
+	lastCycle  time.Time
+	reportErr  error
+}
+
+// agentPollState is the outcome of the last poll of an agent.
+type agentPollState struct {
+	lastPoll time.Time
+	duration time.Duration
+	err      error
+}
+
+func (s *pollState) startCycle(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cycleStart = now
+}
+
+func (s *pollState) endCycle(now time.Time, reportErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cycleStart = time.Time{}
+	s.lastCycle = now
+	s.reportErr = reportErr
+}
+
+// startAgentPoll marks an agent as being polled. It returns false when the
+// previous poll of the agent is still running.
+func (s *pollState) startAgentPoll(agentName string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.polling[agentName] {
+		return false
+	}
+
+	if s.polling == nil {
+		s.polling = make(map[string]bool)
+	}
+
+	s.polling[agentName] = true
+
+	return true
+}
+
+func (s *pollState) endAgentPoll(agentName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.polling, agentName)
+}
+
+// recordAgentPoll records the outcome of polling an agent. The caller must
+// hold s.mu.
+func (s *pollState) recordAgentPoll(agentName string, start time.Time, duration time.Duration, err error) {
+	if s.agents == nil {
+		s.agents = make(map[string]*agentPollState)
+	}
+
+	s.agents[agentName] = &agentPollState{lastPoll: start, duration: duration, err: err}
+}
+
+// StatusResponse is served on /status.
+type StatusResponse struct {
+	PollerID        string                         `json:"poller_id"`
+	CoreAddress     string                         `json:"core_address"`
+	LastPollCycle   time.Time                      `json:"last_poll_cycle,omitzero"`
+	LastReportError string                         `json:"last_report_error,omitempty"`
+	BacklogSize     int                            `json:"backlog_size"` // queued reports
+	Agents          map[string]AgentStatusResponse `json:"agents"`
+}
+
+// AgentStatusResponse describes the connection to an agent and its last poll.
+type AgentStatusResponse struct {
+	Address          string                `json:"address"`
+	ConnectionState  string                `json:"connection_state"`
+	LastPoll         time.Time             `json:"last_poll,omitzero"`
+	LastPollDuration string                `json:"last_poll_duration,omitempty"`
+	LastError        string                `json:"last_error,omitempty"`
+	Checks           []CheckStatusResponse `json:"checks"`
+}
+
+// CheckStatusResponse is the latest result of a check. LastRun is omitted
+// for checks that have not run yet.
+type CheckStatusResponse struct {
+	ServiceName string    `json:"service_name"`
+	ServiceType string    `json:"service_type"`
+	Available   bool      `json:"available"`
+	Message     string    `json:"message,omitempty"`
+	LastRun     time.Time `json:"last_run,omitzero"`
+}
+
+// startStatusServer serves the status, liveness and readiness endpoints on
+// status_addr.
+func (p *Poller) startStatusServer() {
+	srv := &http.Server{
+		Addr:         p.config.StatusAddr,
+		Handler:      p.statusHandler(),
+		ReadTimeout:  statusReadTimeout,
+		WriteTimeout: statusWriteTimeout,
+	}
+
+	p.mu.Lock()
+	p.statusSrv = srv
+	p.mu.Unlock()
+
+	log.Printf("Serving poller status on %s", p.config.StatusAddr)
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Status server error: %v", err)
+		}
+	}()
+}
+
+func (p *Poller) stopStatusServer(ctx context.Context) {
+	p.mu.RLock()
+	srv := p.statusSrv
+	p.mu.RUnlock()
+
+	if srv == nil {
+		return
+	}
+
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("Error shutting down status server: %v", err)
+	}
+}
+
+func (p *Poller) statusHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(p.status()); err != nil {
+			log.Printf("Error encoding status response: %v", err)
+		}
+	})
+
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeProbe(w, p.alive())
+	})
+
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, _ *http.Request) {
+		writeProbe(w, p.ready())
+	})
+
+	return mux
+}
+
+func writeProbe(w http.ResponseWriter, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+
+		return
+	}
+
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// status returns a snapshot of the poller's agents, checks and backlog.
+func (p *Poller) status() *StatusResponse {
+	resp := &StatusResponse{
+		PollerID: p.config.PollerID,
+		Agents:   make(map[string]AgentStatusResponse, len(p.config.Agents)),
+	}
+
+	p.mu.RLock()
+
+	if len(p.config.CoreAddresses) > p.coreIndex {
+		resp.CoreAddress = p.config.CoreAddresses[p.coreIndex]
+	}
+
+	connectionStates := make(map[string]string, len(p.agents))
+
+	for name, agent := range p.agents {
+		connectionStates[name] = agent.client.GetConnection().GetState().String()
+	}
+
+	p.mu.RUnlock()
+
+	if p.backlog != nil {
+		resp.BacklogSize = p.backlog.count()
+	}
+
+	p.state.mu.RLock()
+	defer p.state.mu.RUnlock()
+
+	resp.LastPollCycle = p.state.lastCycle
+
+	if p.state.reportErr != nil {
+		resp.LastReportError = p.state.reportErr.Error()
+	}
+
+	for name, agentConfig := range p.config.Agents {
+		agent := AgentStatusResponse{
+			Address:         agentConfig.Address,
+			ConnectionState: connectionStates[name],
+			Checks:          make([]CheckStatusResponse, 0, len(p.checks[name])),
+		}
+
+		if agent.ConnectionState == "" {
+			agent.ConnectionState = "NOT_CONNECTED"
+		}
+
+		if last, ok := p.state.agents[name]; ok {
+			agent.LastPoll = last.lastPoll
+			agent.LastPollDuration = last.duration.String()
+
+			if last.err != nil {
+				agent.LastError = last.err.Error()
+			}
+		}
+
+		for _, state := range p.checks[name] {
+			check := CheckStatusResponse{
+				ServiceName: state.check.Name,
+				ServiceType: state.check.Type,
+				LastRun:     state.lastRun,
+			}
+
+			if state.status != nil {
+				check.Available = state.status.Available
+				check.Message = state.status.Message
+			}
+
+			agent.Checks = append(agent.Checks, check)
+		}
+
+		resp.Agents[name] = agent
+	}
+
+	return resp
+}
+
+// alive reports whether the poll loop is making progress. A poll cycle waits
+// at most one tick for the agents, so one that runs much longer is stuck.
+func (p *Poller) alive() error {
+	select {
+	case <-p.done:
+		return errPollerStopped
+	default:
+	}
+
+	p.state.mu.RLock()
+	cycleStart := p.state.cycleStart
+	p.state.mu.RUnlock()
+
+	if !cycleStart.IsZero() && time.Since(cycleStart) > p.maxCycleDuration() {
+		return errPollStalled
+	}
+
+	return nil
+}
+
+// ready reports whether the poller has completed a poll cycle and delivered
+// its last report to the core or queued it to the backlog. The backlog depth
+// is reported by the status endpoint.
+func (p *Poller) ready() error {
+	p.state.mu.RLock()
+	defer p.state.mu.RUnlock()
+
+	if p.state.lastCycle.IsZero() {
+		return errNoPollCycle
+	}
+
+	if errors.Is(p.state.reportErr, errReportQueued) {
+		return nil
+	}
+
+	return p.state.reportErr
+}
+
+// maxCycleDuration is the longest a poll cycle can take: one tick waiting for
+// the agents, plus cycleStallMargin to report to the core and replay the backlog.
+func (p *Poller) maxCycleDuration() time.Duration {
+	return p.pollTick() + cycleStallMargin
+}
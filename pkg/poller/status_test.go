@@ -0,0 +1,105 @@
+/*
+ * Copyright 2025 Carver Automation Corporation.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package poller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/carverauto/serviceradar/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getStatus(t *testing.T, handler http.Handler, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+
+	return rec
+}
+
+func TestStatusEndpoints(t *testing.T) {
+	agents := map[string]AgentConfig{
+		"down": {Address: "127.0.0.1:1", Checks: []Check{{Name: "web", Type: "port"}, {Name: "sshd", Type: "process"}}},
+	}
+
+	p := &Poller{
+		config: Config{
+			Agents:        agents,
+			CoreAddresses: []string{"core:50052"},
+			PollerID:      "poller",
+			PollInterval:  config.Duration(time.Minute),
+			AgentTimeout:  config.Duration(time.Second),
+		},
+		coreClient: &fakeCore{down: true},
+		agents:     make(map[string]*AgentConnection),
+		checks:     newCheckStates(agents, time.Minute),
+		backlog:    newTestBacklog(t, defaultBacklogMaxSize, defaultBacklogMaxAge),
+		done:       make(chan struct{}),
+	}
+
+	t.Cleanup(func() { _ = p.Close() })
+
+	handler := p.statusHandler()
+
+	assert.Equal(t, http.StatusOK, getStatus(t, handler, "/healthz").Code)
+	assert.Equal(t, http.StatusServiceUnavailable, getStatus(t, handler, "/readyz").Code, "no poll cycle yet")
+
+	require.Error(t, p.poll(context.Background()))
+
+	rec := getStatus(t, handler, "/status")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var status StatusResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+
+	assert.Equal(t, "poller", status.PollerID)
+	assert.Equal(t, "core:50052", status.CoreAddress)
+	assert.Equal(t, 1, status.BacklogSize)
+	assert.Contains(t, status.LastReportError, "connection refused")
+
+	agent := status.Agents["down"]
+	assert.Equal(t, "127.0.0.1:1", agent.Address)
+	assert.NotZero(t, agent.LastPoll)
+	assert.NotEmpty(t, agent.LastPollDuration)
+	assert.NotEmpty(t, agent.LastError)
+	require.Len(t, agent.Checks, 2)
+	assert.Equal(t, "sshd", agent.Checks[1].ServiceName)
+	assert.False(t, agent.Checks[1].Available)
+	assert.Contains(t, agent.Checks[1].Message, errAgentUnreachable.Error())
+	assert.NotZero(t, agent.Checks[1].LastRun)
+
+	assert.Equal(t, http.StatusOK, getStatus(t, handler, "/readyz").Code, "the report is queued to the backlog")
+
+	p.backlog = nil
+	require.Error(t, p.poll(context.Background()))
+	assert.Equal(t, http.StatusServiceUnavailable, getStatus(t, handler, "/readyz").Code, "the report is lost")
+
+	p.coreClient = &fakeCore{}
+	require.NoError(t, p.poll(context.Background()))
+	assert.Equal(t, http.StatusOK, getStatus(t, handler, "/readyz").Code)
+
+	// A poll cycle that outlives every agent deadline is stuck.
+	p.state.startCycle(time.Now().Add(-time.Hour))
+	assert.Equal(t, http.StatusServiceUnavailable, getStatus(t, handler, "/healthz").Code)
+}
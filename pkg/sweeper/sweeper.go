@@ -135,10 +135,8 @@ func (s *NetworkSweeper) Start(ctx context.Context) error {
 }
 
 // scanAndProcess runs a scan and processes its results.
-func (s *NetworkSweeper) scanAndProcess(ctx context.Context, wg *sync.WaitGroup,
+func (s *NetworkSweeper) scanAndProcess(ctx context.Context,
 	scanner scan.Scanner, targets []models.Target, scanType string) error {
-	defer wg.Done()
-
 	log.Printf("Running %s scan...", scanType)
 
 	results, err := scanner.Scan(ctx, targets)
@@ -197,7 +195,9 @@ func (s *NetworkSweeper) runSweep(ctx context.Context) error {
 		wg.Add(1)
 
 		go func() {
-			icmpErr = s.scanAndProcess(ctx, &wg, s.icmpScanner, icmpTargets, "ICMP")
+			defer wg.Done()
+
+			icmpErr = s.scanAndProcess(ctx, s.icmpScanner, icmpTargets, "ICMP")
 		}()
 	}
 
@@ -205,7 +205,9 @@ func (s *NetworkSweeper) runSweep(ctx context.Context) error {
 		wg.Add(1)
 
 		go func() {
-			tcpErr = s.scanAndProcess(ctx, &wg, s.tcpScanner, tcpTargets, "TCP")
+			defer wg.Done()
+
+			tcpErr = s.scanAndProcess(ctx, s.tcpScanner, tcpTargets, "TCP")
 		}()
 	}
 
@@ -1,3 +1,18 @@
+//
+// Copyright 2025 Carver Automation Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.5.1
@@ -19,7 +34,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AgentService_GetStatus_FullMethodName = "/monitoring.AgentService/GetStatus"
+	AgentService_GetStatus_FullMethodName   = "/monitoring.AgentService/GetStatus"
+	AgentService_GetStatuses_FullMethodName = "/monitoring.AgentService/GetStatuses"
 )
 
 // AgentServiceClient is the client API for AgentService service.
@@ -27,6 +43,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type AgentServiceClient interface {
 	GetStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	GetStatuses(ctx context.Context, in *StatusesRequest, opts ...grpc.CallOption) (*StatusesResponse, error)
 }
 
 type agentServiceClient struct {
@@ -47,11 +64,22 @@ func (c *agentServiceClient) GetStatus(ctx context.Context, in *StatusRequest, o
 	return out, nil
 }
 
+func (c *agentServiceClient) GetStatuses(ctx context.Context, in *StatusesRequest, opts ...grpc.CallOption) (*StatusesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusesResponse)
+	err := c.cc.Invoke(ctx, AgentService_GetStatuses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServiceServer is the server API for AgentService service.
 // All implementations must embed UnimplementedAgentServiceServer
 // for forward compatibility.
 type AgentServiceServer interface {
 	GetStatus(context.Context, *StatusRequest) (*StatusResponse, error)
+	GetStatuses(context.Context, *StatusesRequest) (*StatusesResponse, error)
 	mustEmbedUnimplementedAgentServiceServer()
 }
 
@@ -65,6 +93,9 @@ type UnimplementedAgentServiceServer struct{}
 func (UnimplementedAgentServiceServer) GetStatus(context.Context, *StatusRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedAgentServiceServer) GetStatuses(context.Context, *StatusesRequest) (*StatusesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatuses not implemented")
+}
 func (UnimplementedAgentServiceServer) mustEmbedUnimplementedAgentServiceServer() {}
 func (UnimplementedAgentServiceServer) testEmbeddedByValue()                      {}
 
@@ -104,6 +135,24 @@ func _AgentService_GetStatus_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentService_GetStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).GetStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_GetStatuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).GetStatuses(ctx, req.(*StatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AgentService_ServiceDesc is the grpc.ServiceDesc for AgentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +164,10 @@ var AgentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatus",
 			Handler:    _AgentService_GetStatus_Handler,
 		},
+		{
+			MethodName: "GetStatuses",
+			Handler:    _AgentService_GetStatuses_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/monitoring.proto",